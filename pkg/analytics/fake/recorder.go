@@ -0,0 +1,55 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Package fake provides a Recorder implementation for use in tests.
+package fake
+
+import (
+	"sync"
+
+	"github.com/arangodb/kube-arangodb/pkg/analytics"
+)
+
+// Recorder records every event it receives in memory, for use in tests that assert on
+// what an operator would have sent to the analytics collector.
+type Recorder struct {
+	mu          sync.Mutex
+	Deployments []analytics.DeploymentEvent
+	Heartbeats  []analytics.HeartbeatEvent
+}
+
+// New creates an empty Recorder.
+func New() *Recorder {
+	return &Recorder{}
+}
+
+// RecordDeploymentEvent implements analytics.Recorder.
+func (r *Recorder) RecordDeploymentEvent(ev analytics.DeploymentEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Deployments = append(r.Deployments, ev)
+}
+
+// RecordHeartbeat implements analytics.Recorder.
+func (r *Recorder) RecordHeartbeat(ev analytics.HeartbeatEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Heartbeats = append(r.Heartbeats, ev)
+}
@@ -0,0 +1,79 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package analytics
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// httpRecorder posts every event as JSON to a configured HTTPS collector. All network
+// calls happen on their own goroutine with a bounded timeout so RecordDeploymentEvent and
+// RecordHeartbeat never block the caller.
+type httpRecorder struct {
+	url        string
+	trackingID string
+	client     *http.Client
+}
+
+func newHTTPRecorder(url, trackingID string, timeout time.Duration) *httpRecorder {
+	return &httpRecorder{
+		url:        url,
+		trackingID: trackingID,
+		client:     &http.Client{Timeout: timeout},
+	}
+}
+
+type envelope struct {
+	TrackingID string      `json:"tracking_id,omitempty"`
+	Kind       string      `json:"kind"`
+	Payload    interface{} `json:"payload"`
+}
+
+func (r *httpRecorder) RecordDeploymentEvent(ev DeploymentEvent) {
+	r.postAsync(envelope{TrackingID: r.trackingID, Kind: "deployment", Payload: ev})
+}
+
+func (r *httpRecorder) RecordHeartbeat(ev HeartbeatEvent) {
+	r.postAsync(envelope{TrackingID: r.trackingID, Kind: "heartbeat", Payload: ev})
+}
+
+// postAsync posts env to the collector on its own goroutine. Any failure is logged and
+// otherwise ignored.
+func (r *httpRecorder) postAsync(env envelope) {
+	go func() {
+		body, err := json.Marshal(env)
+		if err != nil {
+			log.Debug().Err(err).Msg("Failed to marshal analytics event")
+			return
+		}
+		resp, err := r.client.Post(r.url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			log.Debug().Err(err).Msg("Failed to deliver analytics event")
+			return
+		}
+		resp.Body.Close()
+	}()
+}
@@ -0,0 +1,103 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Package analytics emits anonymized usage events for ArangoDeployments to an optional
+// HTTPS collector. It is opt-in and must never fail or slow down reconciliation: every
+// Recorder method is fire-and-forget.
+package analytics
+
+import "time"
+
+// EventType identifies the kind of anonymized usage event being recorded.
+type EventType string
+
+const (
+	EventDeploymentProvision   EventType = "deployment-provision"
+	EventDeploymentDeprovision EventType = "deployment-deprovision"
+)
+
+// DeploymentEvent is the anonymized payload recorded for a single deployment create/delete.
+// None of its fields may reveal user-chosen names: ClientID is derived from the
+// kube-system namespace UID and AppID from the deployment UID, never from a namespace or
+// deployment name.
+type DeploymentEvent struct {
+	ClientID        string
+	AppID           string
+	Type            EventType
+	Mode            string
+	Environment     string
+	DatabaseVersion string
+	Enterprise      bool
+	PodCount        int
+	VolumeCount     int
+	StorageClasses  int
+	StorageRequests int64 // sum of storage requests across all StorageClasses, in bytes
+}
+
+// HeartbeatEvent is the anonymized payload recorded by the 24h per-operator-process ticker.
+type HeartbeatEvent struct {
+	ClientID           string
+	NodeCount          int
+	KubernetesVersion  string
+	DeploymentsPerMode map[string]int
+}
+
+// Recorder records anonymized usage events. Implementations must be safe for concurrent
+// use and must never block or return an error to the caller: a Recorder degrading or
+// disappearing must never fail reconciliation.
+type Recorder interface {
+	// RecordDeploymentEvent records a deployment create/delete.
+	RecordDeploymentEvent(ev DeploymentEvent)
+	// RecordHeartbeat records the periodic per-operator-process heartbeat.
+	RecordHeartbeat(ev HeartbeatEvent)
+}
+
+// Config configures the Recorder built by New.
+type Config struct {
+	// Enabled turns on analytics. Defaults to off; see ARANGODB_OPERATOR_ENABLE_ANALYTICS.
+	Enabled bool
+	// CollectorURL is the HTTPS endpoint events are posted to.
+	CollectorURL string
+	// TrackingID is an optional identifier for the installation, included verbatim in
+	// every event; it is operator-chosen and must not be derived from anything in-cluster.
+	TrackingID string
+	// Timeout bounds every HTTP call made by the Recorder. Defaults to requestTimeout.
+	Timeout time.Duration
+}
+
+const requestTimeout = 5 * time.Second
+
+// New builds a Recorder from cfg. When cfg.Enabled is false, it returns a no-op Recorder.
+func New(cfg Config) Recorder {
+	if !cfg.Enabled || cfg.CollectorURL == "" {
+		return noopRecorder{}
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = requestTimeout
+	}
+	return newHTTPRecorder(cfg.CollectorURL, cfg.TrackingID, timeout)
+}
+
+// noopRecorder discards every event. It is the default when analytics are disabled.
+type noopRecorder struct{}
+
+func (noopRecorder) RecordDeploymentEvent(ev DeploymentEvent) {}
+func (noopRecorder) RecordHeartbeat(ev HeartbeatEvent)        {}
@@ -0,0 +1,104 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package analytics
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// forbiddenFieldNames are substrings that would indicate a field carries a user-chosen
+// name straight through to the analytics collector, rather than an anonymized identifier.
+var forbiddenFieldNames = []string{"name", "namespace"}
+
+// assertNoPIIFields fails t if any field of v's type has a name containing one of
+// forbiddenFieldNames. ClientID/AppID are derived identifiers, not names, and are allowed.
+func assertNoPIIFields(t *testing.T, v interface{}) {
+	t.Helper()
+	typ := reflect.TypeOf(v)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i).Name
+		lower := strings.ToLower(field)
+		for _, forbidden := range forbiddenFieldNames {
+			if strings.Contains(lower, forbidden) {
+				t.Errorf("%s.%s looks like it could carry a user-chosen name/namespace", typ.Name(), field)
+			}
+		}
+	}
+}
+
+func TestDeploymentEventHasNoPIIFields(t *testing.T) {
+	assertNoPIIFields(t, DeploymentEvent{})
+}
+
+func TestHeartbeatEventHasNoPIIFields(t *testing.T) {
+	assertNoPIIFields(t, HeartbeatEvent{})
+}
+
+// assertNoLeakedInputs fails t if the JSON encoding of v contains any of inputs verbatim.
+// Unlike assertNoPIIFields, this catches a field whose name looks innocuous (e.g.
+// ClusterHost) but whose value is actually one of the raw, user-chosen inputs.
+func assertNoLeakedInputs(t *testing.T, v interface{}, inputs ...string) {
+	t.Helper()
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to marshal %T: %v", v, err)
+	}
+	for _, input := range inputs {
+		if strings.Contains(string(encoded), input) {
+			t.Errorf("encoded %T leaks raw input %q: %s", v, input, encoded)
+		}
+	}
+}
+
+// TestDeploymentEventValuesDoNotLeakInputs hand-builds a DeploymentEvent with the kind of
+// values recordAnalyticsEvent (pkg/deployment/analytics.go) fills in, next to raw inputs
+// that look like real cluster state: a customer namespace/deployment name and a private
+// registry host. It does not call recordAnalyticsEvent. It inspects the actual encoded
+// values, not the field names, so a field like ClusterHost holding one of these raw
+// strings would be caught even though its name doesn't match forbiddenFieldNames.
+func TestDeploymentEventValuesDoNotLeakInputs(t *testing.T) {
+	const (
+		namespace        = "customer-prod"
+		deploymentName   = "orders-db"
+		privateRegistry  = "registry.customer-internal.example.com"
+		kubeSystemNSUID  = "a1b2c3d4-0000-4000-8000-000000000001"
+		deploymentObjUID = "f9e8d7c6-0000-4000-8000-000000000002"
+	)
+
+	ev := DeploymentEvent{
+		ClientID:        kubeSystemNSUID,
+		AppID:           deploymentObjUID,
+		Type:            EventDeploymentProvision,
+		Mode:            "cluster",
+		Environment:     "production",
+		DatabaseVersion: "3.11.0",
+		Enterprise:      true,
+		PodCount:        9,
+		VolumeCount:     6,
+		StorageClasses:  2,
+		StorageRequests: 107374182400,
+	}
+
+	assertNoLeakedInputs(t, ev, namespace, deploymentName, privateRegistry)
+}
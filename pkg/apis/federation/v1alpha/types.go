@@ -0,0 +1,81 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1alpha
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FederatedArangoDeployment is a specification of a single ArangoDB cluster whose
+// agency/coordinator/dbserver groups are spread over several member Kubernetes clusters,
+// joined into one logical ArangoDB cluster over a WAN.
+type FederatedArangoDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FederatedArangoDeploymentSpec   `json:"spec"`
+	Status FederatedArangoDeploymentStatus `json:"status"`
+}
+
+// FederatedArangoDeploymentSpec holds the desired state of a FederatedArangoDeployment.
+type FederatedArangoDeploymentSpec struct {
+	// Members lists the Kubernetes clusters that together host this deployment.
+	Members []FederationMemberSpec `json:"members"`
+}
+
+// FederationMemberSpec describes a single member cluster of a FederatedArangoDeployment.
+type FederationMemberSpec struct {
+	// ClusterName is the user-facing name of this member cluster.
+	ClusterName string `json:"clusterName"`
+	// KubeconfigSecretName is the name (in the FederatedArangoDeployment's namespace) of a
+	// Secret holding a kubeconfig for ClusterName. Empty means "this cluster" (in-cluster config).
+	KubeconfigSecretName string `json:"kubeconfigSecretName,omitempty"`
+	// Agents, DBServers and Coordinators select how many of each group this member hosts.
+	Agents       int `json:"agents,omitempty"`
+	DBServers    int `json:"dbservers,omitempty"`
+	Coordinators int `json:"coordinators,omitempty"`
+}
+
+// FederatedArangoDeploymentStatus holds the observed state of a FederatedArangoDeployment,
+// aggregated from the status of the child ArangoDeployment in every member cluster.
+type FederatedArangoDeploymentStatus struct {
+	Members []FederationMemberStatus `json:"members,omitempty"`
+}
+
+// FederationMemberStatus is the last observed status of a single member cluster's child
+// ArangoDeployment.
+type FederationMemberStatus struct {
+	ClusterName string `json:"clusterName"`
+	Phase       string `json:"phase"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FederatedArangoDeploymentList is a list of FederatedArangoDeployments.
+type FederatedArangoDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []FederatedArangoDeployment `json:"items"`
+}
@@ -0,0 +1,116 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Package query is a minimal Go client for the operator's GET
+// /api/deployment/{ns}/{name}/members endpoint. It deliberately does not import
+// pkg/server or pkg/deployment, so other controllers or dashboards can query member status
+// without pulling in the whole operator.
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Member is a single member as returned by the members endpoint.
+type Member struct {
+	ID      string `json:"id"`
+	PodName string `json:"pod_name"`
+	PVCName string `json:"pvc_name"`
+	PVName  string `json:"pv_name"`
+}
+
+// Query filters the members returned by Client.QueryMembers. A nil pointer or empty
+// slice/string means "don't filter on this field". Groups use the REST names accepted by
+// the operator (e.g. "dbservers", "coordinators"), not the internal api.ServerGroup values.
+type Query struct {
+	Groups            []string
+	Ready             *bool
+	PodPhases         []string
+	PVCBound          *bool
+	ImageID           string
+	VersionConstraint string // e.g. ">=3.5.0"
+}
+
+// encode turns q into the URL query parameters accepted by the members endpoint.
+func (q Query) encode() url.Values {
+	v := url.Values{}
+	for _, g := range q.Groups {
+		v.Add("group", g)
+	}
+	if q.Ready != nil {
+		v.Set("ready", strconv.FormatBool(*q.Ready))
+	}
+	for _, p := range q.PodPhases {
+		v.Add("pod_phase", p)
+	}
+	if q.PVCBound != nil {
+		v.Set("pvc_bound", strconv.FormatBool(*q.PVCBound))
+	}
+	if q.ImageID != "" {
+		v.Set("image_id", q.ImageID)
+	}
+	if q.VersionConstraint != "" {
+		v.Set("version", q.VersionConstraint)
+	}
+	return v
+}
+
+// Client is a client for the operator's member query API.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string // e.g. "https://arango-operator.kube-system.svc:8528"
+}
+
+// NewClient creates a Client that talks to the operator at baseURL.
+func NewClient(baseURL string) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    baseURL,
+	}
+}
+
+// QueryMembers fetches the members of the ArangoDeployment named name, in namespace ns,
+// that match q.
+func (c *Client) QueryMembers(ns, name string, q Query) ([]Member, error) {
+	u := fmt.Sprintf("%s/api/deployment/%s/%s/members?%s", c.baseURL, url.PathEscape(ns), url.PathEscape(name), q.encode().Encode())
+
+	resp, err := c.httpClient.Get(u)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, maskAny(fmt.Errorf("members query failed with status %s", resp.Status))
+	}
+
+	var result struct {
+		Members []Member `json:"members"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, maskAny(err)
+	}
+	return result.Members, nil
+}
@@ -0,0 +1,106 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Package connector abstracts how to reach a Kubernetes cluster hosting ArangoDB members:
+// a kubeconfig Secret, the operator's own in-cluster config, or a remote API server
+// reached through a bearer token stored in a Secret.
+package connector
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
+)
+
+// SourceType selects how a Connection reaches its cluster.
+type SourceType string
+
+const (
+	// SourceInCluster means "the cluster the operator itself runs in".
+	SourceInCluster SourceType = "in-cluster"
+	// SourceKubeconfigSecret means the connection is built from a kubeconfig stored in
+	// a Secret in the operator's own cluster.
+	SourceKubeconfigSecret SourceType = "kubeconfig-secret"
+	// SourceBearerTokenSecret means the connection is built from an API server URL and a
+	// bearer token, both stored in a Secret in the operator's own cluster.
+	SourceBearerTokenSecret SourceType = "bearer-token-secret"
+)
+
+// ConnectionConfig describes how to reach a single remote cluster.
+type ConnectionConfig struct {
+	ClusterName string
+	Source      SourceType
+	// SecretName is the Secret (in the operator's own namespace) backing a
+	// SourceKubeconfigSecret or SourceBearerTokenSecret connection.
+	SecretName string
+}
+
+// hash returns a stable key identifying this ConnectionConfig, used to cache the
+// clientsets built from it across reconciles.
+func (c ConnectionConfig) hash() string {
+	return fmt.Sprintf("%s/%s/%s", c.Source, c.SecretName, c.ClusterName)
+}
+
+// Clients holds the typed clientsets for a single connected cluster.
+type Clients struct {
+	KubeCli       kubernetes.Interface
+	DatabaseCRCli versioned.Interface
+}
+
+// restConfigFromSecret builds a *rest.Config for cfg from the referenced Secret.
+func restConfigFromSecret(localKubeCli kubernetes.Interface, namespace string, cfg ConnectionConfig) (*rest.Config, error) {
+	secret, err := localKubeCli.CoreV1().Secrets(namespace).Get(cfg.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	switch cfg.Source {
+	case SourceKubeconfigSecret:
+		kubeconfig, found := secret.Data["kubeconfig"]
+		if !found {
+			return nil, fmt.Errorf("secret '%s' has no 'kubeconfig' key", cfg.SecretName)
+		}
+		return clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	case SourceBearerTokenSecret:
+		server, found := secret.Data["server"]
+		if !found {
+			return nil, fmt.Errorf("secret '%s' has no 'server' key", cfg.SecretName)
+		}
+		token, found := secret.Data["token"]
+		if !found {
+			return nil, fmt.Errorf("secret '%s' has no 'token' key", cfg.SecretName)
+		}
+		caCert := secret.Data["ca.crt"]
+		return &rest.Config{
+			Host:        string(server),
+			BearerToken: string(token),
+			TLSClientConfig: rest.TLSClientConfig{
+				CAData: caCert,
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported connector source type '%s'", cfg.Source)
+	}
+}
@@ -0,0 +1,106 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package connector
+
+import (
+	"sync"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
+)
+
+// Registry caches the Clients built for each distinct ConnectionConfig (keyed by a hash
+// of the connection config) so repeated reconciles of the same deployment do not rebuild
+// a clientset on every sync. A Registry may be shared by several ArangoDeployments:
+// Register may be called whenever Clients for a config are needed and only builds them on
+// first use, Unregister is called when a deployment using that config is deleted.
+type Registry struct {
+	localKubeCli kubernetes.Interface
+	namespace    string
+
+	mu      sync.RWMutex
+	clients map[string]Clients // ConnectionConfig.hash() -> Clients
+}
+
+// NewRegistry creates an empty Registry. localKubeCli/namespace are used to resolve the
+// Secrets backing SourceKubeconfigSecret/SourceBearerTokenSecret connections.
+func NewRegistry(localKubeCli kubernetes.Interface, namespace string) *Registry {
+	return &Registry{
+		localKubeCli: localKubeCli,
+		namespace:    namespace,
+		clients:      make(map[string]Clients),
+	}
+}
+
+// Register builds (or returns the cached) Clients for cfg.
+func (r *Registry) Register(cfg ConnectionConfig) (Clients, error) {
+	key := cfg.hash()
+
+	r.mu.RLock()
+	if c, found := r.clients[key]; found {
+		r.mu.RUnlock()
+		return c, nil
+	}
+	r.mu.RUnlock()
+
+	c, err := r.build(cfg)
+	if err != nil {
+		return Clients{}, maskAny(err)
+	}
+
+	r.mu.Lock()
+	r.clients[key] = c
+	r.mu.Unlock()
+
+	return c, nil
+}
+
+// Unregister drops the cached Clients for cfg. Call this once the deployment that
+// registered cfg is deleted so the Registry does not grow without bound.
+func (r *Registry) Unregister(cfg ConnectionConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.clients, cfg.hash())
+}
+
+// build constructs the Clients for cfg.
+func (r *Registry) build(cfg ConnectionConfig) (Clients, error) {
+	if cfg.Source == SourceInCluster {
+		return Clients{}, nil // caller is expected to fall back to its own local Dependencies
+	}
+
+	restConfig, err := restConfigFromSecret(r.localKubeCli, r.namespace, cfg)
+	if err != nil {
+		return Clients{}, maskAny(err)
+	}
+
+	kubeCli, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return Clients{}, maskAny(err)
+	}
+	dbCli, err := versioned.NewForConfig(restConfig)
+	if err != nil {
+		return Clients{}, maskAny(err)
+	}
+
+	return Clients{KubeCli: kubeCli, DatabaseCRCli: dbCli}, nil
+}
@@ -0,0 +1,97 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Package connector's tests cover Registry/restConfigFromSecret against a fake Kubernetes
+// clientset. They do not stand in for the e2e, multi-kind-cluster coverage the member
+// aggregation feature (pkg/deployment's remoteMembers, pkg/federation's Controller) really
+// needs: spinning up member clusters and asserting member status flows between them end to
+// end requires a real/kind environment this repo's test suite does not have access to.
+package connector
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRegistryInCluster(t *testing.T) {
+	r := NewRegistry(fake.NewSimpleClientset(), "default")
+
+	clients, err := r.Register(ConnectionConfig{ClusterName: "local", Source: SourceInCluster})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if clients.KubeCli != nil || clients.DatabaseCRCli != nil {
+		t.Fatalf("expected an empty Clients for SourceInCluster, got %+v", clients)
+	}
+}
+
+func TestRegistryBearerTokenSecretIsCached(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "member-a", Namespace: "default"},
+		Data: map[string][]byte{
+			"server": []byte("https://member-a.example.com"),
+			"token":  []byte("s3cr3t"),
+		},
+	}
+	kubeCli := fake.NewSimpleClientset(secret)
+	r := NewRegistry(kubeCli, "default")
+	cfg := ConnectionConfig{ClusterName: "member-a", Source: SourceBearerTokenSecret, SecretName: "member-a"}
+
+	first, err := r.Register(cfg)
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if first.KubeCli == nil || first.DatabaseCRCli == nil {
+		t.Fatalf("expected non-nil clientsets for SourceBearerTokenSecret, got %+v", first)
+	}
+
+	// Deleting the backing Secret must not affect a second Register call: the Clients for
+	// cfg should already be cached and not rebuilt from the (now missing) Secret.
+	if err := kubeCli.CoreV1().Secrets("default").Delete(secret.Name, nil); err != nil {
+		t.Fatalf("failed to delete secret: %v", err)
+	}
+	second, err := r.Register(cfg)
+	if err != nil {
+		t.Fatalf("Register on cached config failed: %v", err)
+	}
+	if second.KubeCli == nil {
+		t.Fatalf("expected the cached Clients to still be returned, got %+v", second)
+	}
+
+	r.Unregister(cfg)
+	if _, err := r.Register(cfg); err == nil {
+		t.Fatalf("expected Register to fail after Unregister dropped the cache and the secret is gone")
+	}
+}
+
+func TestRegistryUnsupportedSource(t *testing.T) {
+	// The backing Secret exists, so the error can only come from the unknown source type.
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "member-b", Namespace: "default"},
+	}
+	r := NewRegistry(fake.NewSimpleClientset(secret), "default")
+
+	if _, err := r.Register(ConnectionConfig{ClusterName: "member-b", Source: "bogus", SecretName: "member-b"}); err == nil {
+		t.Fatal("expected an error for an unsupported connector source type")
+	}
+}
@@ -0,0 +1,82 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/analytics"
+)
+
+// recordAnalyticsEvent records a deployment-provision/deprovision analytics.DeploymentEvent
+// for this deployment. It never fails reconciliation: errors resolving the client id are
+// logged and the event is dropped.
+//
+// Every field is anonymized: ClientID is derived from the kube-system namespace UID,
+// AppID from the deployment's own UID, never from any user-chosen name.
+func (d *Deployment) recordAnalyticsEvent(t analytics.EventType) {
+	clientID, err := d.analyticsClientID()
+	if err != nil {
+		d.deps.Log.Debug().Err(err).Msg("Failed to determine analytics client id")
+		return
+	}
+
+	version, license := d.DatabaseVersion()
+	d.deps.Analytics.RecordDeploymentEvent(analytics.DeploymentEvent{
+		ClientID:        clientID,
+		AppID:           string(d.apiObject.UID),
+		Type:            t,
+		Mode:            string(d.Mode()),
+		Environment:     string(d.Environment()),
+		DatabaseVersion: version,
+		Enterprise:      license == "enterprise",
+		PodCount:        d.PodCount(),
+		VolumeCount:     d.VolumeCount(),
+		StorageClasses:  len(d.StorageClasses()),
+		StorageRequests: d.storageRequestsBytes(),
+	})
+}
+
+// analyticsClientID returns a stable, anonymous identifier for the Kubernetes cluster the
+// operator runs in, derived from the kube-system namespace UID.
+func (d *Deployment) analyticsClientID() (string, error) {
+	ns, err := d.deps.KubeCli.CoreV1().Namespaces().Get("kube-system", metav1.GetOptions{})
+	if err != nil {
+		return "", maskAny(err)
+	}
+	return string(ns.GetUID()), nil
+}
+
+// storageRequestsBytes sums the storage requests of all PVCs owned by this deployment.
+func (d *Deployment) storageRequestsBytes() int64 {
+	pvcs, err := d.GetOwnedPVCs()
+	if err != nil {
+		return 0
+	}
+	var total int64
+	for _, pvc := range pvcs {
+		if qty, found := pvc.Spec.Resources.Requests[v1.ResourceStorage]; found {
+			total += qty.Value()
+		}
+	}
+	return total
+}
@@ -0,0 +1,414 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/arangodb/kube-arangodb/pkg/analytics"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1alpha"
+	"github.com/arangodb/kube-arangodb/pkg/connector"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/events"
+	arangoinformers "github.com/arangodb/kube-arangodb/pkg/generated/informers/externalversions"
+	arangolisters "github.com/arangodb/kube-arangodb/pkg/generated/listers/arangodb/v1alpha"
+	"github.com/arangodb/kube-arangodb/pkg/server"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+// controllerResyncPeriod is the resync period used for all informers owned by the
+// DeploymentController. It replaces the old fixed inspectionInterval ticker: a resync
+// requeues every known ArangoDeployment key even when no watch event fired on it.
+const controllerResyncPeriod = time.Minute
+
+// analyticsHeartbeatInterval is how often the controller records an analytics heartbeat
+// for the whole operator process (node count, Kubernetes server version, per-mode
+// deployment counts), independent of any single deployment's lifecycle.
+const analyticsHeartbeatInterval = 24 * time.Hour
+
+var (
+	queueDepthMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "arangodb_operator",
+		Subsystem: "deployment_controller",
+		Name:      "queue_depth",
+		Help:      "Number of ArangoDeployment keys currently queued for reconciliation",
+	})
+	syncLatencyMetric = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "arangodb_operator",
+		Subsystem: "deployment_controller",
+		Name:      "sync_latency_seconds",
+		Help:      "Time spent in a single syncHandler call",
+		Buckets:   prometheus.DefBuckets,
+	})
+	reconcileErrorsMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "arangodb_operator",
+		Subsystem: "deployment_controller",
+		Name:      "reconcile_errors_total",
+		Help:      "Number of syncHandler calls that returned an error",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(queueDepthMetric, syncLatencyMetric, reconcileErrorsMetric)
+}
+
+// DeploymentController watches ArangoDeployments (and the resources they own: Pod, PVC,
+// Service, Secret and the ArangoBackup* types) through shared informers and drives
+// reconciliation of individual deployments through a single rate limited workqueue.
+//
+// This replaces the old per-Deployment eventCh/stopCh/inspectTrigger combination: every
+// change that used to be pushed into a deployment's private channel is now translated
+// into an enqueue of the owning ArangoDeployment's key, and the fixed inspectionInterval
+// ticker is replaced by the informer factory's resync period.
+type DeploymentController struct {
+	config Config
+	deps   Dependencies
+
+	kubeInformerFactory   informers.SharedInformerFactory
+	arangoInformerFactory arangoinformers.SharedInformerFactory
+	deploymentLister      arangolisters.ArangoDeploymentLister
+
+	// remoteRegistry caches the clientsets built for every ArangoDeployment's
+	// Spec.RemoteClusters entries; see getOrCreateDeployment and syncHandler's delete path.
+	remoteRegistry *connector.Registry
+
+	queue workqueue.RateLimitingInterface
+
+	mu          sync.RWMutex
+	deployments map[string]*Deployment // key (ns/name) -> in memory Deployment state
+}
+
+// NewDeploymentController creates a DeploymentController that reconciles ArangoDeployments
+// in the given namespace using shared informers build from deps.KubeCli / deps.DatabaseCRCli.
+func NewDeploymentController(config Config, deps Dependencies, namespace string) *DeploymentController {
+	kubeInformerFactory := informers.NewSharedInformerFactoryWithOptions(deps.KubeCli, controllerResyncPeriod,
+		informers.WithNamespace(namespace))
+	arangoInformerFactory := arangoinformers.NewSharedInformerFactoryWithOptions(deps.DatabaseCRCli, controllerResyncPeriod,
+		arangoinformers.WithNamespace(namespace))
+
+	arangoDeployments := arangoInformerFactory.Database().V1alpha().ArangoDeployments()
+
+	dc := &DeploymentController{
+		config:                config,
+		deps:                  deps,
+		kubeInformerFactory:   kubeInformerFactory,
+		arangoInformerFactory: arangoInformerFactory,
+		deploymentLister:      arangoDeployments.Lister(),
+		remoteRegistry:        connector.NewRegistry(deps.KubeCli, namespace),
+		queue:                 workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "arangodeployments"),
+		deployments:           make(map[string]*Deployment),
+	}
+
+	arangoDeployments.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { dc.enqueue(obj) },
+		UpdateFunc: func(old, new interface{}) { dc.enqueue(new) },
+		DeleteFunc: func(obj interface{}) { dc.enqueue(obj) },
+	})
+
+	// Pods, PVCs, Services, Secrets and ArangoBackups only ever trigger a requeue of
+	// their *owning* deployment; the sync handler re-derives desired state from scratch,
+	// so there is no need to track what specifically changed (replacing eventPodAdded /
+	// eventPodUpdated / eventPodDeleted).
+	ownedInformers := []cache.SharedIndexInformer{
+		kubeInformerFactory.Core().V1().Pods().Informer(),
+		kubeInformerFactory.Core().V1().PersistentVolumeClaims().Informer(),
+		kubeInformerFactory.Core().V1().Services().Informer(),
+		kubeInformerFactory.Core().V1().Secrets().Informer(),
+		arangoInformerFactory.Backup().V1().ArangoBackups().Informer(),
+	}
+	for _, informer := range ownedInformers {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { dc.enqueueOwner(obj) },
+			UpdateFunc: func(old, new interface{}) { dc.enqueueOwner(new) },
+			DeleteFunc: func(obj interface{}) { dc.enqueueOwner(obj) },
+		})
+	}
+
+	return dc
+}
+
+// enqueue adds the key of an ArangoDeployment object to the workqueue.
+func (dc *DeploymentController) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	dc.queue.Add(key)
+	queueDepthMetric.Set(float64(dc.queue.Len()))
+}
+
+// enqueueOwner looks up the ArangoDeployment that owns obj (through its OwnerReferences,
+// replacing the old isOwnerOf helper) and enqueues that deployment's key.
+func (dc *DeploymentController) enqueueOwner(obj interface{}) {
+	metaObj, err := objectMeta(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, ref := range metaObj.GetOwnerReferences() {
+		if ref.Kind != "ArangoDeployment" {
+			continue
+		}
+		dc.queue.Add(metaObj.GetNamespace() + "/" + ref.Name)
+		queueDepthMetric.Set(float64(dc.queue.Len()))
+		return
+	}
+}
+
+// objectMeta extracts the metav1.Object from a runtime object, unwrapping the tombstone
+// left behind when a DeleteFunc fires after the informer cache already evicted the object.
+func objectMeta(obj interface{}) (metav1.Object, error) {
+	if m, ok := obj.(metav1.Object); ok {
+		return m, nil
+	}
+	tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+	if !ok {
+		return nil, fmt.Errorf("object has no meta: %v", obj)
+	}
+	m, ok := tombstone.Obj.(metav1.Object)
+	if !ok {
+		return nil, fmt.Errorf("tombstone contained object that is not metav1.Object: %v", tombstone.Obj)
+	}
+	return m, nil
+}
+
+// Run starts the informers and the given number of workers processing items from the
+// workqueue. It blocks until stopCh is closed.
+func (dc *DeploymentController) Run(workers int, stopCh <-chan struct{}) error {
+	defer runtime.HandleCrash()
+	defer dc.queue.ShutDown()
+
+	dc.kubeInformerFactory.Start(stopCh)
+	dc.arangoInformerFactory.Start(stopCh)
+
+	if ok := cache.WaitForCacheSync(stopCh,
+		dc.arangoInformerFactory.Database().V1alpha().ArangoDeployments().Informer().HasSynced,
+		dc.arangoInformerFactory.Backup().V1().ArangoBackups().Informer().HasSynced,
+		dc.kubeInformerFactory.Core().V1().Pods().Informer().HasSynced,
+		dc.kubeInformerFactory.Core().V1().PersistentVolumeClaims().Informer().HasSynced,
+		dc.kubeInformerFactory.Core().V1().Services().Informer().HasSynced,
+		dc.kubeInformerFactory.Core().V1().Secrets().Informer().HasSynced,
+	); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(dc.runWorker, time.Second, stopCh)
+	}
+
+	go wait.Until(dc.recordAnalyticsHeartbeat, analyticsHeartbeatInterval, stopCh)
+
+	<-stopCh
+	return nil
+}
+
+func (dc *DeploymentController) runWorker() {
+	for dc.processNextWorkItem() {
+	}
+}
+
+func (dc *DeploymentController) processNextWorkItem() bool {
+	key, shutdown := dc.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer dc.queue.Done(key)
+	queueDepthMetric.Set(float64(dc.queue.Len()))
+
+	start := time.Now()
+	err := dc.syncHandler(key.(string))
+	syncLatencyMetric.Observe(time.Since(start).Seconds())
+
+	if err == nil {
+		dc.queue.Forget(key)
+		return true
+	}
+
+	reconcileErrorsMetric.Inc()
+	runtime.HandleError(fmt.Errorf("syncHandler failed for %q: %v, requeuing", key, err))
+	dc.queue.AddRateLimited(key)
+	return true
+}
+
+// syncHandler fetches the ArangoDeployment identified by key from the lister cache and
+// (re)applies the createServices -> createInitialMembers -> ensurePVCs -> ensurePods ->
+// inspectPods sequence idempotently. Returning an error causes the workqueue to retry
+// this key with exponential backoff; failOnError/reportFailedStatus are still used to
+// surface a terminal failure on the CR status.
+func (dc *DeploymentController) syncHandler(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	apiObject, err := dc.deploymentLister.ArangoDeployments(namespace).Get(name)
+	if err != nil {
+		if k8sutil.IsNotFound(err) {
+			// Deployment was deleted; tear down its remote-cluster connections (if any)
+			// and drop any in-memory state kept for it.
+			dc.mu.Lock()
+			d, found := dc.deployments[key]
+			delete(dc.deployments, key)
+			dc.mu.Unlock()
+			if found {
+				d.UnregisterRemoteClusters()
+			}
+			return nil
+		}
+		return maskAny(err)
+	}
+
+	d, err := dc.getOrCreateDeployment(key, apiObject)
+	if err != nil {
+		return maskAny(err)
+	}
+
+	if err := d.createServices(apiObject); err != nil {
+		d.failOnError(err, "Failed to create services")
+		return maskAny(err)
+	}
+	if err := d.createInitialMembers(apiObject); err != nil {
+		d.failOnError(err, "Failed to create initial members")
+		return maskAny(err)
+	}
+	if err := d.ensurePVCs(apiObject); err != nil {
+		d.failOnError(err, "Failed to create persistent volume claims")
+		return maskAny(err)
+	}
+	if err := d.ensurePods(apiObject); err != nil {
+		d.failOnError(err, "Failed to create pods")
+		return maskAny(err)
+	}
+	if err := d.inspectPods(); err != nil {
+		d.createEvent(k8sutil.NewErrorEvent("Pod inspection failed", err, apiObject))
+		d.emitCloudEvent(events.TypeMemberFailed, "", server.NewDeploymentInfo(d))
+	}
+
+	d.status.State = api.DeploymentStateRunning
+	if err := d.updateCRStatus(); err != nil {
+		dc.deps.Log.Warn().Err(err).Msg("update CR status failed")
+	}
+
+	return nil
+}
+
+// recordAnalyticsHeartbeat records a single analytics.HeartbeatEvent for the whole
+// operator process: node count, Kubernetes server version and a per-mode count of
+// currently known deployments. It never fails: errors are logged and the heartbeat for
+// this tick is dropped.
+func (dc *DeploymentController) recordAnalyticsHeartbeat() {
+	clientID, err := dc.analyticsClientID()
+	if err != nil {
+		dc.deps.Log.Debug().Err(err).Msg("Failed to determine analytics client id")
+		return
+	}
+
+	nodes, err := dc.deps.KubeCli.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		dc.deps.Log.Debug().Err(err).Msg("Failed to list nodes for analytics heartbeat")
+		return
+	}
+
+	serverVersion, err := dc.deps.KubeCli.Discovery().ServerVersion()
+	if err != nil {
+		dc.deps.Log.Debug().Err(err).Msg("Failed to fetch server version for analytics heartbeat")
+		return
+	}
+
+	dc.mu.RLock()
+	perMode := make(map[string]int)
+	for _, d := range dc.deployments {
+		perMode[string(d.Mode())]++
+	}
+	dc.mu.RUnlock()
+
+	dc.deps.Analytics.RecordHeartbeat(analytics.HeartbeatEvent{
+		ClientID:           clientID,
+		NodeCount:          len(nodes.Items),
+		KubernetesVersion:  serverVersion.String(),
+		DeploymentsPerMode: perMode,
+	})
+}
+
+// analyticsClientID returns a stable, anonymous identifier for the Kubernetes cluster the
+// operator runs in, derived from the kube-system namespace UID.
+func (dc *DeploymentController) analyticsClientID() (string, error) {
+	ns, err := dc.deps.KubeCli.CoreV1().Namespaces().Get("kube-system", metav1.GetOptions{})
+	if err != nil {
+		return "", maskAny(err)
+	}
+	return string(ns.GetUID()), nil
+}
+
+// getOrCreateDeployment returns the in-memory Deployment for key, creating it (without
+// starting the old run()/listenForPodEvents goroutines, which the controller now replaces)
+// on first sight of the key.
+func (dc *DeploymentController) getOrCreateDeployment(key string, apiObject *api.ArangoDeployment) (*Deployment, error) {
+	dc.mu.RLock()
+	d, found := dc.deployments[key]
+	dc.mu.RUnlock()
+	if found {
+		d.apiObject = apiObject
+		return d, nil
+	}
+
+	d, err := newDeploymentState(dc.config, dc.deps, apiObject)
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	if clusters := remoteConnectionConfigsFor(apiObject); len(clusters) > 0 {
+		d.RegisterRemoteClusters(dc.remoteRegistry, clusters)
+	}
+
+	dc.mu.Lock()
+	dc.deployments[key] = d
+	dc.mu.Unlock()
+	return d, nil
+}
+
+// remoteConnectionConfigsFor builds the connector.ConnectionConfig list describing every
+// additional Kubernetes cluster apiObject's Spec.RemoteClusters declares, so its members can
+// be aggregated alongside the local cluster's (see remote_members.go).
+func remoteConnectionConfigsFor(apiObject *api.ArangoDeployment) []connector.ConnectionConfig {
+	remotes := apiObject.Spec.RemoteClusters
+	if len(remotes) == 0 {
+		return nil
+	}
+	configs := make([]connector.ConnectionConfig, 0, len(remotes))
+	for _, rc := range remotes {
+		configs = append(configs, connector.ConnectionConfig{
+			ClusterName: rc.ClusterName,
+			Source:      connector.SourceKubeconfigSecret,
+			SecretName:  rc.KubeconfigSecretName,
+		})
+	}
+	return configs
+}
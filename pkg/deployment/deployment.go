@@ -23,8 +23,10 @@
 package deployment
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -34,16 +36,29 @@ import (
 	"k8s.io/client-go/kubernetes"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
-	api "github.com/arangodb/k8s-operator/pkg/apis/arangodb/v1alpha"
-	"github.com/arangodb/k8s-operator/pkg/generated/clientset/versioned"
-	"github.com/arangodb/k8s-operator/pkg/util/k8sutil"
-	"github.com/arangodb/k8s-operator/pkg/util/retry"
-	"github.com/arangodb/k8s-operator/pkg/util/trigger"
+	uuid "k8s.io/apimachinery/pkg/util/uuid"
+
+	"github.com/arangodb/kube-arangodb/pkg/analytics"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1alpha"
+	"github.com/arangodb/kube-arangodb/pkg/connector"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/events"
+	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
+	"github.com/arangodb/kube-arangodb/pkg/server"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/arangodb/kube-arangodb/pkg/util/retry"
 )
 
+// subscriberQueueSize is the buffer depth of a single Subscribe channel. A subscriber that
+// falls this far behind is dropped rather than allowed to block reconciliation.
+const subscriberQueueSize = 8
+
 // Config holds configuration settings for a Deployment
 type Config struct {
 	ServiceAccount string
+	// EventSink configures where CloudEvents for lifecycle transitions are published.
+	// The zero value disables CloudEvents emission; native v1.Event objects recorded
+	// through createEvent are unaffected either way.
+	EventSink events.Config
 }
 
 // Dependencies holds dependent services for a Deployment
@@ -51,175 +66,147 @@ type Dependencies struct {
 	Log           zerolog.Logger
 	KubeCli       kubernetes.Interface
 	DatabaseCRCli versioned.Interface
+	// Analytics records anonymized usage events. Shared by every Deployment in the
+	// operator process so a single 24h heartbeat ticker can be run against it; defaults
+	// to a no-op recorder when analytics are disabled.
+	Analytics analytics.Recorder
 }
 
-// deploymentEventType strongly typed type of event
-type deploymentEventType string
-
-const (
-	eventArangoDeploymentUpdated deploymentEventType = "ArangoDeploymentUpdated"
-	eventPodAdded                deploymentEventType = "PodAdded"
-	eventPodUpdated              deploymentEventType = "PodUpdated"
-	eventPodDeleted              deploymentEventType = "PodDeleted"
-)
-
-// deploymentEvent holds an event passed from the controller to the deployment.
-type deploymentEvent struct {
-	Type       deploymentEventType
-	Deployment *api.ArangoDeployment
-	Pod        *v1.Pod
-}
-
-const (
-	deploymentEventQueueSize = 100
-	inspectionInterval       = time.Minute // Ensure we inspect the generated resources no less than with this interval
-)
-
-// Deployment is the in process state of an ArangoDeployment.
+// Deployment is the in process state of an ArangoDeployment, reconciled by a
+// DeploymentController's syncHandler rather than by a private event loop: there is no
+// eventCh/stopCh/inspectTrigger here anymore, reconciliation is driven entirely by the
+// controller's workqueue.
 type Deployment struct {
 	apiObject *api.ArangoDeployment // API object
 	status    api.DeploymentStatus  // Internal status of the CR
 	config    Config
 	deps      Dependencies
 
-	eventCh chan *deploymentEvent
-	stopCh  chan struct{}
-
 	eventsCli corev1.EventInterface
+	eventSink events.Sink
 
-	inspectTrigger trigger.Trigger
+	subscribersMu sync.Mutex
+	subscribers   map[chan server.DeploymentInfoDetails]struct{}
+
+	// remoteRegistry/remoteClusters/memberLocator support aggregating members across the
+	// additional Kubernetes clusters declared in Spec.RemoteClusters; see
+	// RegisterRemoteClusters in remote_members.go. nil when this deployment is single-cluster.
+	remoteRegistry *connector.Registry
+	remoteClusters []connector.ConnectionConfig
+	memberLocator  *MemberLocator
 }
 
 // New creates a new Deployment from the given API object.
+// Unlike before, New no longer starts any goroutines; the owning DeploymentController
+// drives reconciliation of the returned Deployment through syncHandler/workqueue
+// enqueues instead.
 func New(config Config, deps Dependencies, apiObject *api.ArangoDeployment) (*Deployment, error) {
+	return newDeploymentState(config, deps, apiObject)
+}
+
+// newDeploymentState validates apiObject and builds the in-memory Deployment for it.
+// It is split out from New so DeploymentController.getOrCreateDeployment can construct
+// a Deployment without going through any exported, possibly side-effecting API.
+func newDeploymentState(config Config, deps Dependencies, apiObject *api.ArangoDeployment) (*Deployment, error) {
 	if err := apiObject.Spec.Validate(); err != nil {
 		return nil, maskAny(err)
 	}
+	sink, err := events.NewSink(config.EventSink, deps.Log)
+	if err != nil {
+		return nil, maskAny(err)
+	}
 	d := &Deployment{
-		apiObject: apiObject,
-		status:    *(apiObject.Status.DeepCopy()),
-		config:    config,
-		deps:      deps,
-		eventCh:   make(chan *deploymentEvent, deploymentEventQueueSize),
-		stopCh:    make(chan struct{}),
-		eventsCli: deps.KubeCli.Core().Events(apiObject.GetNamespace()),
+		apiObject:   apiObject,
+		status:      *(apiObject.Status.DeepCopy()),
+		config:      config,
+		deps:        deps,
+		eventsCli:   deps.KubeCli.Core().Events(apiObject.GetNamespace()),
+		eventSink:   sink,
+		subscribers: make(map[chan server.DeploymentInfoDetails]struct{}),
 	}
-
-	go d.run()
-	go d.listenForPodEvents()
-
+	d.recordAnalyticsEvent(analytics.EventDeploymentProvision)
 	return d, nil
 }
 
-// Update the deployment.
-// This sends an update event in the deployment event queue.
-func (d *Deployment) Update(apiObject *api.ArangoDeployment) {
-	d.send(&deploymentEvent{
-		Type:       eventArangoDeploymentUpdated,
-		Deployment: apiObject,
-	})
+// cloudEventSource returns the CloudEvents "source" attribute identifying this deployment.
+func (d *Deployment) cloudEventSource() string {
+	return fmt.Sprintf("/apis/database.arangodb.com/v1alpha/namespaces/%s/arangodeployments/%s", d.apiObject.Namespace, d.apiObject.Name)
 }
 
-// Delete the deployment.
-// Called when the deployment was deleted by the user.
-func (d *Deployment) Delete() {
-	d.deps.Log.Info().Msg("deployment is deleted by user")
-	close(d.stopCh)
+// emitCloudEvent publishes a CloudEvent for a lifecycle transition through the configured
+// EventSink. This happens in addition to, never instead of, the native v1.Event objects
+// recorded through createEvent.
+func (d *Deployment) emitCloudEvent(t events.Type, subject string, data interface{}) {
+	d.eventSink.Send(events.Event{
+		ID:      string(uuid.NewUUID()),
+		Source:  d.cloudEventSource(),
+		Type:    t,
+		Subject: subject,
+		Time:    time.Now(),
+		Data:    data,
+	})
 }
 
-// send given event into the deployment event queue.
-func (d *Deployment) send(ev *deploymentEvent) {
-	select {
-	case d.eventCh <- ev:
-		l, ecap := len(d.eventCh), cap(d.eventCh)
-		if l > int(float64(ecap)*0.8) {
-			d.deps.Log.Warn().
-				Int("used", l).
-				Int("capacity", ecap).
-				Msg("event queue buffer is almost full")
-		}
-	case <-d.stopCh:
-	}
+// Subscribe returns a channel that receives a new DeploymentInfoDetails snapshot every
+// time updateCRStatus observes a change to this deployment's status (pod added/updated/
+// deleted, member role change, phase transition, version upgrade). The channel is closed
+// when ctx is cancelled.
+func (d *Deployment) Subscribe(ctx context.Context) <-chan server.DeploymentInfoDetails {
+	ch := make(chan server.DeploymentInfoDetails, subscriberQueueSize)
+
+	d.subscribersMu.Lock()
+	d.subscribers[ch] = struct{}{}
+	d.subscribersMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		d.subscribersMu.Lock()
+		delete(d.subscribers, ch)
+		d.subscribersMu.Unlock()
+		close(ch)
+	}()
+
+	return ch
 }
 
-// run is the core the core worker.
-// It processes the event queue and polls the state of generated
-// resource on a regular basis.
-func (d *Deployment) run() {
-	log := d.deps.Log
-
-	// Create services
-	if err := d.createServices(d.apiObject); err != nil {
-		d.failOnError(err, "Failed to create services")
-		return
-	}
+// broadcast sends the current state of the deployment to every active subscriber.
+// A subscriber whose channel is full is skipped for this update rather than blocking
+// reconciliation; it will pick up the next broadcast or, at worst, the next watch resync.
+func (d *Deployment) broadcast() {
+	info := server.NewDeploymentInfoDetails(d)
 
-	// Create members
-	if err := d.createInitialMembers(d.apiObject); err != nil {
-		d.failOnError(err, "Failed to create initial members")
-		return
-	}
-
-	// Create PVCs
-	if err := d.ensurePVCs(d.apiObject); err != nil {
-		d.failOnError(err, "Failed to create persistent volume claims")
-		return
-	}
-
-	// Create pods
-	if err := d.ensurePods(d.apiObject); err != nil {
-		d.failOnError(err, "Failed to create pods")
-		return
-	}
-
-	d.status.State = api.DeploymentStateRunning
-	if err := d.updateCRStatus(); err != nil {
-		log.Warn().Err(err).Msg("update initial CR status failed")
-	}
-	log.Info().Msg("start running...")
-
-	for {
+	d.subscribersMu.Lock()
+	defer d.subscribersMu.Unlock()
+	for ch := range d.subscribers {
 		select {
-		case <-d.stopCh:
-			// We're being stopped.
-			return
-
-		case event := <-d.eventCh:
-			// Got event from event queue
-			switch event.Type {
-			case eventArangoDeploymentUpdated:
-				if err := d.handleArangoDeploymentUpdatedEvent(event); err != nil {
-					d.failOnError(err, "Failed to handle deployment update")
-					return
-				}
-			case eventPodAdded, eventPodUpdated, eventPodDeleted:
-				// Pod event received, let's inspect soon
-				d.inspectTrigger.Trigger()
-			default:
-				panic("unknown event type" + event.Type)
-			}
-
-		case <-d.inspectTrigger.Done():
-			// Inspection of generated resources needed
-			if err := d.inspectPods(); err != nil {
-				d.createEvent(k8sutil.NewErrorEvent("Pod inspection failed", err, d.apiObject))
-			}
-			// Ensure all resources are created
-			if err := d.ensurePods(d.apiObject); err != nil {
-				d.createEvent(k8sutil.NewErrorEvent("Pod creation failed", err, d.apiObject))
-			}
-
-		case <-time.After(inspectionInterval):
-			// Trigger inspection
-			d.inspectTrigger.Trigger()
+		case ch <- info:
+		default:
+			d.deps.Log.Warn().Msg("watch subscriber is falling behind, dropping update")
 		}
 	}
 }
 
-// handleArangoDeploymentUpdatedEvent is called when the deployment is updated by the user.
-func (d *Deployment) handleArangoDeploymentUpdatedEvent(event *deploymentEvent) error {
-	// TODO
-	return nil
+// Reconciled returns true once the controller's syncHandler has reconciled this deployment
+// at least once, i.e. d.status has moved away from its zero value. Used by the operator's
+// /healthz endpoint to distinguish "just created, not yet synced" from "synced and failed".
+func (d *Deployment) Reconciled() bool {
+	return d.status.State != ""
+}
+
+// Update the deployment.
+// The actual reconciliation happens asynchronously: the ArangoDeployment informer that
+// observed this update has already enqueued the deployment's key on the controller's
+// workqueue, so this only needs to keep the cached API object current.
+func (d *Deployment) Update(apiObject *api.ArangoDeployment) {
+	d.apiObject = apiObject
+}
+
+// Delete the deployment.
+// Called when the deployment was deleted by the user. Cleanup of in-memory state is
+// handled by the controller's syncHandler once it observes the ArangoDeployment is gone.
+func (d *Deployment) Delete() {
+	d.deps.Log.Info().Msg("deployment is deleted by user")
+	d.recordAnalyticsEvent(analytics.EventDeploymentDeprovision)
 }
 
 // createEvent creates a given event.
@@ -237,6 +224,7 @@ func (d *Deployment) updateCRStatus() error {
 		// Nothing has changed
 		return nil
 	}
+	previousState := d.apiObject.Status.State
 
 	// Send update to API server
 	update := d.apiObject.DeepCopy()
@@ -249,6 +237,13 @@ func (d *Deployment) updateCRStatus() error {
 	// Update internal object
 	d.apiObject = newAPIObject
 
+	// Let any /watch subscribers know the deployment moved
+	d.broadcast()
+
+	if d.status.State == api.DeploymentStateRunning && previousState != api.DeploymentStateRunning {
+		d.emitCloudEvent(events.TypeDeploymentCreated, "", server.NewDeploymentInfo(d))
+	}
+
 	return nil
 }
 
@@ -256,6 +251,7 @@ func (d *Deployment) updateCRStatus() error {
 func (d *Deployment) failOnError(err error, msg string) {
 	log.Error().Err(err).Msg(msg)
 	d.status.Reason = err.Error()
+	d.emitCloudEvent(events.TypeStateFailed, "", server.NewDeploymentInfo(d))
 	d.reportFailedStatus()
 }
 
@@ -294,13 +290,4 @@ func (d *Deployment) reportFailedStatus() {
 	}
 
 	retry.Retry(op, time.Hour*24*365)
-}
-
-// isOwnerOf returns true if the given object belong to this deployment.
-func (d *Deployment) isOwnerOf(obj metav1.Object) bool {
-	ownerRefs := obj.GetOwnerReferences()
-	if len(ownerRefs) < 1 {
-		return false
-	}
-	return ownerRefs[0].UID == d.apiObject.UID
 }
\ No newline at end of file
@@ -0,0 +1,81 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// httpSink POSTs every Event to a configured URL using the CloudEvents binary content
+// mode: the envelope attributes go into ce-* headers, and the body is the raw JSON data.
+type httpSink struct {
+	endpoint string
+	client   *http.Client
+	log      zerolog.Logger
+}
+
+func newHTTPSink(endpoint string, log zerolog.Logger) *httpSink {
+	return &httpSink{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 5 * time.Second},
+		log:      log,
+	}
+}
+
+// Send posts ev to the configured endpoint. Failures are logged, never returned: no
+// transition may be blocked on the availability of the event collector.
+func (s *httpSink) Send(ev Event) {
+	body, err := json.Marshal(ev.Data)
+	if err != nil {
+		s.log.Error().Err(err).Str("type", string(ev.Type)).Msg("Failed to marshal CloudEvent data")
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to build CloudEvent HTTP request")
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("ce-specversion", "1.0")
+	req.Header.Set("ce-id", ev.ID)
+	req.Header.Set("ce-source", ev.Source)
+	req.Header.Set("ce-type", string(ev.Type))
+	req.Header.Set("ce-time", ev.Time.Format(time.RFC3339Nano))
+	if ev.Subject != "" {
+		req.Header.Set("ce-subject", ev.Subject)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.log.Warn().Err(err).Str("type", string(ev.Type)).Msg("Failed to deliver CloudEvent")
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		s.log.Warn().Int("status", resp.StatusCode).Str("type", string(ev.Type)).Msg("CloudEvent collector returned an error status")
+	}
+}
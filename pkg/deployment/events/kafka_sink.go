@@ -0,0 +1,97 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/rs/zerolog"
+)
+
+// kafkaSink produces every Event as a single CloudEvents structured-content-mode message
+// (the full envelope, including attributes, as one JSON document) to a configured topic.
+type kafkaSink struct {
+	topic    string
+	producer sarama.SyncProducer
+	log      zerolog.Logger
+}
+
+// structuredEnvelope is the structured content mode CloudEvents JSON document.
+type structuredEnvelope struct {
+	SpecVersion string      `json:"specversion"`
+	ID          string      `json:"id"`
+	Source      string      `json:"source"`
+	Type        string      `json:"type"`
+	Subject     string      `json:"subject,omitempty"`
+	Time        time.Time   `json:"time"`
+	Data        interface{} `json:"data"`
+}
+
+func newKafkaSink(brokers []string, topic string, log zerolog.Logger) *kafkaSink {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	config.Producer.RequiredAcks = sarama.WaitForLocal
+	config.Producer.Timeout = 5 * time.Second
+
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		// A Kafka sink that cannot connect yet must still never block reconciliation;
+		// fall back to logging every Send instead of failing Deployment construction.
+		log.Error().Err(err).Strs("brokers", brokers).Msg("Failed to create Kafka producer for CloudEvents, events will be dropped")
+		return &kafkaSink{topic: topic, producer: nil, log: log}
+	}
+
+	return &kafkaSink{topic: topic, producer: producer, log: log}
+}
+
+// Send produces ev onto the configured topic. Failures are logged, never returned.
+func (s *kafkaSink) Send(ev Event) {
+	if s.producer == nil {
+		s.log.Warn().Str("type", string(ev.Type)).Msg("Dropping CloudEvent, no Kafka producer available")
+		return
+	}
+
+	envelope := structuredEnvelope{
+		SpecVersion: "1.0",
+		ID:          ev.ID,
+		Source:      ev.Source,
+		Type:        string(ev.Type),
+		Subject:     ev.Subject,
+		Time:        ev.Time,
+		Data:        ev.Data,
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		s.log.Error().Err(err).Str("type", string(ev.Type)).Msg("Failed to marshal CloudEvent envelope")
+		return
+	}
+
+	msg := &sarama.ProducerMessage{
+		Topic: s.topic,
+		Key:   sarama.StringEncoder(ev.Subject),
+		Value: sarama.ByteEncoder(body),
+	}
+	if _, _, err := s.producer.SendMessage(msg); err != nil {
+		s.log.Warn().Err(err).Str("type", string(ev.Type)).Msg("Failed to deliver CloudEvent to Kafka")
+	}
+}
@@ -0,0 +1,85 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package events
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+)
+
+// SinkType selects which Sink implementation NewSink builds.
+type SinkType string
+
+const (
+	SinkTypeNone SinkType = ""
+	SinkTypeHTTP SinkType = "http"
+	SinkTypeKafka SinkType = "kafka"
+)
+
+// Config configures the CloudEvents sink used by a Deployment.
+type Config struct {
+	// Type selects the sink implementation. The zero value (SinkTypeNone) disables
+	// CloudEvents emission entirely.
+	Type SinkType
+	// HTTPEndpoint is the URL events are POSTed to when Type == SinkTypeHTTP.
+	HTTPEndpoint string
+	// KafkaBrokers is the list of broker addresses used when Type == SinkTypeKafka.
+	KafkaBrokers []string
+	// KafkaTopic is the topic events are produced to when Type == SinkTypeKafka.
+	KafkaTopic string
+}
+
+// Sink publishes Events emitted by a Deployment. Implementations must not block the
+// reconcile loop for long and must never return an error that a caller would treat as
+// fatal; Send should log and drop on failure.
+type Sink interface {
+	// Send publishes ev. It must not panic and should return quickly; if delivery fails
+	// it must log the failure itself rather than propagate an error, so that no
+	// transition can ever be held up by this sink.
+	Send(ev Event)
+}
+
+// NewSink builds the Sink described by cfg. An empty Type yields a no-op sink; an
+// unrecognised Type is an error.
+func NewSink(cfg Config, log zerolog.Logger) (Sink, error) {
+	switch cfg.Type {
+	case SinkTypeNone:
+		return noopSink{}, nil
+	case SinkTypeHTTP:
+		if cfg.HTTPEndpoint == "" {
+			return nil, fmt.Errorf("HTTPEndpoint must be set for sink type '%s'", SinkTypeHTTP)
+		}
+		return newHTTPSink(cfg.HTTPEndpoint, log), nil
+	case SinkTypeKafka:
+		if cfg.KafkaTopic == "" || len(cfg.KafkaBrokers) == 0 {
+			return nil, fmt.Errorf("KafkaBrokers and KafkaTopic must be set for sink type '%s'", SinkTypeKafka)
+		}
+		return newKafkaSink(cfg.KafkaBrokers, cfg.KafkaTopic, log), nil
+	default:
+		return nil, fmt.Errorf("unknown event sink type '%s'", cfg.Type)
+	}
+}
+
+// noopSink discards every event. It is the default when Config.Type is empty.
+type noopSink struct{}
+
+func (noopSink) Send(ev Event) {}
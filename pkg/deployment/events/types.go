@@ -0,0 +1,58 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Package events emits CNCF CloudEvents (spec v1.0) for ArangoDeployment lifecycle
+// transitions, alongside (not instead of) the native v1.Event objects recorded by
+// Deployment.createEvent.
+package events
+
+import "time"
+
+// Type is a CloudEvents "type" attribute identifying a kind of deployment lifecycle
+// transition.
+type Type string
+
+const (
+	TypeDeploymentCreated Type = "com.arangodb.deployment.created"
+	TypeMemberAdded       Type = "com.arangodb.deployment.member.added"
+	TypeMemberReady       Type = "com.arangodb.deployment.member.ready"
+	TypeMemberFailed      Type = "com.arangodb.deployment.member.failed"
+	TypeUpgradeStarted    Type = "com.arangodb.deployment.upgrade.started"
+	TypeUpgradeCompleted  Type = "com.arangodb.deployment.upgrade.completed"
+	TypeBackupCreated     Type = "com.arangodb.deployment.backup.created"
+	TypeStateFailed       Type = "com.arangodb.deployment.state.failed"
+)
+
+// Event is the payload handed to a Sink for a single lifecycle transition.
+type Event struct {
+	// ID uniquely identifies this event (CloudEvents "id").
+	ID string
+	// Source is the CloudEvents "source", e.g.
+	// /apis/database.arangodb.com/v1alpha/namespaces/{ns}/arangodeployments/{name}.
+	Source string
+	// Type is the CloudEvents "type".
+	Type Type
+	// Subject identifies the affected member ID, when this event concerns one member.
+	Subject string
+	// Time is the CloudEvents "time".
+	Time time.Time
+	// Data is the CloudEvents JSON payload: a server.DeploymentInfo or server.MemberInfo.
+	Data interface{}
+}
@@ -0,0 +1,49 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1alpha"
+)
+
+// Ready returns true if m's Ready condition is set, looking across the local cluster and,
+// for a multi-cluster deployment, every remote cluster m.d knows about.
+func (m member) Ready() bool {
+	statuses := append([]api.DeploymentStatus{m.d.status}, m.d.remoteStatuses()...)
+	for _, status := range statuses {
+		ready := false
+		status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+			if group != m.group {
+				return nil
+			}
+			for _, ms := range list {
+				if ms.ID == m.id && ms.Conditions.IsTrue(api.ConditionTypeReady) {
+					ready = true
+				}
+			}
+			return nil
+		})
+		if ready {
+			return true
+		}
+	}
+	return false
+}
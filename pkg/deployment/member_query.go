@@ -0,0 +1,230 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1alpha"
+	"github.com/arangodb/kube-arangodb/pkg/server"
+)
+
+// QueryMembers returns the members of the deployment matching q, pre-joined with their pod
+// and PVC status. Unlike ReadyVolumeCount, which scans the PVC list once per member,
+// QueryMembers lists pods and PVCs a single time each and indexes them by name. The ready
+// filter goes through member.Ready, which rescans the member statuses (and, for a
+// multi-cluster deployment, fetches every remote cluster's status) once per member.
+func (d *Deployment) QueryMembers(q server.MemberQuery) ([]server.Member, error) {
+	pods, err := d.GetOwnedPods()
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	podByName := make(map[string]v1.Pod, len(pods))
+	for _, p := range pods {
+		podByName[p.Name] = p
+	}
+
+	pvcs, err := d.GetOwnedPVCs()
+	if err != nil {
+		return nil, maskAny(err)
+	}
+	pvcByName := make(map[string]v1.PersistentVolumeClaim, len(pvcs))
+	for _, pvc := range pvcs {
+		pvcByName[pvc.Name] = pvc
+	}
+
+	status, _ := d.GetStatus()
+	var result []server.Member
+	status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+		if !matchesGroup(q, group) {
+			return nil
+		}
+		for _, m := range list {
+			mm := member{d: d, id: m.ID, group: group}
+			pod, hasPod := podByName[m.PodName]
+			pvc, hasPVC := pvcByName[m.PersistentVolumeClaimName]
+			if matchesMember(q, mm, optionalPod(pod, hasPod), optionalPVC(pvc, hasPVC)) {
+				result = append(result, mm)
+			}
+		}
+		return nil
+	})
+	return result, nil
+}
+
+func optionalPod(pod v1.Pod, ok bool) *v1.Pod {
+	if !ok {
+		return nil
+	}
+	return &pod
+}
+
+func optionalPVC(pvc v1.PersistentVolumeClaim, ok bool) *v1.PersistentVolumeClaim {
+	if !ok {
+		return nil
+	}
+	return &pvc
+}
+
+// memberQuery is a local alias for readability; the matching logic below lives in
+// pkg/deployment rather than on server.MemberQuery itself, so pkg/server does not need to
+// know about the v1.Pod/v1.PersistentVolumeClaim joins it requires.
+type memberQuery = server.MemberQuery
+
+func matchesGroup(q memberQuery, group api.ServerGroup) bool {
+	if len(q.Groups) == 0 {
+		return true
+	}
+	for _, g := range q.Groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesMember(q memberQuery, m member, pod *v1.Pod, pvc *v1.PersistentVolumeClaim) bool {
+	if q.Ready != nil && m.Ready() != *q.Ready {
+		return false
+	}
+	if len(q.PodPhases) > 0 {
+		if pod == nil {
+			return false
+		}
+		matched := false
+		for _, phase := range q.PodPhases {
+			if pod.Status.Phase == phase {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if q.PVCBound != nil {
+		bound := pvc != nil && pvc.Status.Phase == v1.ClaimBound
+		if bound != *q.PVCBound {
+			return false
+		}
+	}
+	if q.ImageID != "" {
+		if pod == nil || !podHasImage(pod, q.ImageID) {
+			return false
+		}
+	}
+	if q.VersionConstraint != "" {
+		if pod == nil || !podSatisfiesVersionConstraint(pod, q.VersionConstraint) {
+			return false
+		}
+	}
+	return true
+}
+
+// podHasImage reports whether any container of pod runs image (matched on the full
+// image reference, e.g. "arangodb/arangodb:3.5.1").
+func podHasImage(pod *v1.Pod, image string) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Image == image {
+			return true
+		}
+	}
+	return false
+}
+
+// podSatisfiesVersionConstraint reports whether the ArangoDB version tag of any of pod's
+// container images satisfies constraint (e.g. ">=3.5.0"). Only a single comparator is
+// supported; this is not a full semver range implementation, just enough to let a caller
+// filter members by version during a rolling upgrade.
+func podSatisfiesVersionConstraint(pod *v1.Pod, constraint string) bool {
+	for _, c := range pod.Spec.Containers {
+		if satisfiesVersionConstraint(imageTag(c.Image), constraint) {
+			return true
+		}
+	}
+	return false
+}
+
+// imageTag returns the tag portion of a "repo/image:tag" reference, or "" if there is none.
+func imageTag(image string) string {
+	idx := strings.LastIndex(image, ":")
+	if idx < 0 || idx < strings.LastIndex(image, "/") {
+		return ""
+	}
+	return image[idx+1:]
+}
+
+// satisfiesVersionConstraint reports whether version satisfies a constraint such as
+// ">=3.5.0".
+func satisfiesVersionConstraint(version, constraint string) bool {
+	if version == "" {
+		return false
+	}
+	op, want := splitVersionConstraint(constraint)
+	cmp := compareVersions(version, want)
+	switch op {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp == 0
+	}
+}
+
+// splitVersionConstraint splits a constraint like ">=3.5.0" into its comparator and version.
+func splitVersionConstraint(constraint string) (op string, version string) {
+	for _, candidate := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(constraint, candidate) {
+			return candidate, strings.TrimPrefix(constraint, candidate)
+		}
+	}
+	return "=", constraint
+}
+
+// compareVersions compares two dot-separated numeric versions, returning -1, 0 or 1.
+func compareVersions(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var an, bn int
+		if i < len(as) {
+			an, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			bn, _ = strconv.Atoi(bs[i])
+		}
+		if an != bn {
+			if an < bn {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,128 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1alpha"
+	"github.com/arangodb/kube-arangodb/pkg/connector"
+	"github.com/arangodb/kube-arangodb/pkg/server"
+)
+
+// MemberLocator records which cluster each member of a multi-cluster deployment lives on,
+// so that code reading a member's pod/PVC details (e.g. member.PodDetails()) knows which
+// cluster's clientset to read from instead of assuming the local one.
+type MemberLocator struct {
+	mu        sync.RWMutex
+	locations map[string]string // member ID -> cluster name ("" means the local cluster)
+}
+
+// NewMemberLocator creates an empty MemberLocator.
+func NewMemberLocator() *MemberLocator {
+	return &MemberLocator{locations: make(map[string]string)}
+}
+
+// Set records that member id lives in clusterName.
+func (l *MemberLocator) Set(id, clusterName string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.locations[id] = clusterName
+}
+
+// ClusterOf returns the cluster name for member id, or "" (the local cluster) if unknown.
+func (l *MemberLocator) ClusterOf(id string) string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.locations[id]
+}
+
+// RegisterRemoteClusters records the clusters declared in Spec.RemoteClusters and the
+// registry used to cache their clientsets across reconciles. It does not connect to them:
+// remoteStatuses builds each cluster's clientsets through registry on first use.
+// Call this once when the ArangoDeployment is first observed; call
+// UnregisterRemoteClusters once it is deleted.
+func (d *Deployment) RegisterRemoteClusters(registry *connector.Registry, clusters []connector.ConnectionConfig) {
+	d.remoteRegistry = registry
+	d.remoteClusters = clusters
+	d.memberLocator = NewMemberLocator()
+}
+
+// UnregisterRemoteClusters releases the clientsets cached for this deployment's remote
+// clusters.
+func (d *Deployment) UnregisterRemoteClusters() {
+	if d.remoteRegistry == nil {
+		return
+	}
+	for _, cfg := range d.remoteClusters {
+		d.remoteRegistry.Unregister(cfg)
+	}
+}
+
+// remoteStatuses fetches the status of this deployment's child ArangoDeployment in every
+// remote cluster declared in Spec.RemoteClusters. A cluster that cannot be reached is
+// skipped; PodCount()/ReadyPodCount()/VolumeCount()/Members() are best-effort aggregates,
+// not a strongly consistent view across clusters.
+func (d *Deployment) remoteStatuses() []api.DeploymentStatus {
+	if d.remoteRegistry == nil {
+		return nil
+	}
+
+	var statuses []api.DeploymentStatus
+	for _, cfg := range d.remoteClusters {
+		clients, err := d.remoteRegistry.Register(cfg)
+		if err != nil {
+			d.deps.Log.Debug().Err(err).Str("cluster", cfg.ClusterName).Msg("Failed to connect to remote cluster")
+			continue
+		}
+		remote, err := clients.DatabaseCRCli.DatabaseV1alpha().ArangoDeployments(d.apiObject.Namespace).Get(d.apiObject.Name, metav1.GetOptions{})
+		if err != nil {
+			d.deps.Log.Debug().Err(err).Str("cluster", cfg.ClusterName).Msg("Failed to fetch remote deployment status")
+			continue
+		}
+		statuses = append(statuses, remote.Status)
+
+		remote.Status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+			for _, m := range list {
+				d.memberLocator.Set(m.ID, cfg.ClusterName)
+			}
+			return nil
+		})
+	}
+	return statuses
+}
+
+// remoteMembers returns the server.Member list contributed by every remote cluster, by
+// server group, to be merged into Members().
+func (d *Deployment) remoteMembers() map[api.ServerGroup][]server.Member {
+	result := make(map[api.ServerGroup][]server.Member)
+	for _, status := range d.remoteStatuses() {
+		status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+			for _, m := range list {
+				result[group] = append(result[group], member{d: d, id: m.ID, group: group})
+			}
+			return nil
+		})
+	}
+	return result
+}
@@ -30,6 +30,7 @@ import (
 
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1alpha"
 	"github.com/arangodb/kube-arangodb/pkg/server"
@@ -56,55 +57,52 @@ func (d *Deployment) Environment() api.Environment {
 	return d.GetSpec().GetEnvironment()
 }
 
-// PodCount returns the number of pods for the deployment
-func (d *Deployment) PodCount() int {
+// countMembers returns the number of members across status (and, for a multi-cluster
+// deployment, every connected remote cluster's status) for which pred returns true.
+func (d *Deployment) countMembers(pred func(api.MemberStatus) bool) int {
 	count := 0
-	status, _ := d.GetStatus()
-	status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
-		for _, m := range list {
-			if m.PodName != "" {
-				count++
+	statuses := append([]api.DeploymentStatus{d.status}, d.remoteStatuses()...)
+	for _, status := range statuses {
+		status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+			for _, m := range list {
+				if pred(m) {
+					count++
+				}
 			}
-		}
-		return nil
-	})
+			return nil
+		})
+	}
 	return count
 }
 
-// ReadyPodCount returns the number of pods for the deployment that are in ready state
+// PodCount returns the number of pods for the deployment, aggregated across every
+// connected cluster when the deployment spans more than one.
+func (d *Deployment) PodCount() int {
+	return d.countMembers(func(m api.MemberStatus) bool {
+		return m.PodName != ""
+	})
+}
+
+// ReadyPodCount returns the number of pods for the deployment that are in ready state,
+// aggregated across every connected cluster when the deployment spans more than one.
 func (d *Deployment) ReadyPodCount() int {
-	count := 0
-	status, _ := d.GetStatus()
-	status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
-		for _, m := range list {
-			if m.PodName == "" {
-				continue
-			}
-			if m.Conditions.IsTrue(api.ConditionTypeReady) {
-				count++
-			}
-		}
-		return nil
+	return d.countMembers(func(m api.MemberStatus) bool {
+		return m.PodName != "" && m.Conditions.IsTrue(api.ConditionTypeReady)
 	})
-	return count
 }
 
-// VolumeCount returns the number of volumes for the deployment
+// VolumeCount returns the number of volumes for the deployment, aggregated across every
+// connected cluster when the deployment spans more than one.
 func (d *Deployment) VolumeCount() int {
-	count := 0
-	status, _ := d.GetStatus()
-	status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
-		for _, m := range list {
-			if m.PersistentVolumeClaimName != "" {
-				count++
-			}
-		}
-		return nil
+	return d.countMembers(func(m api.MemberStatus) bool {
+		return m.PersistentVolumeClaimName != ""
 	})
-	return count
 }
 
-// ReadyVolumeCount returns the number of volumes for the deployment that are in ready state
+// ReadyVolumeCount returns the number of volumes for the deployment that are in ready state.
+// This intentionally only counts local-cluster volumes: GetOwnedPVCs only lists PVCs owned
+// by this deployment's local ArangoDeployment, and a remote cluster's PVCs are not ours to
+// own a reference to, so there is nothing to correlate m.PersistentVolumeClaimName against.
 func (d *Deployment) ReadyVolumeCount() int {
 	count := 0
 	status, _ := d.GetStatus()
@@ -152,35 +150,214 @@ func (d *Deployment) StorageClasses() []string {
 
 // DatabaseURL returns an URL to reach the database from outside the Kubernetes cluster
 // Empty string means that the database is not reachable outside the Kubernetes cluster.
+// On a dual-stack external-access Service this returns the first of DatabaseURLs, kept
+// only for callers that have not moved to the per-family list yet.
 func (d *Deployment) DatabaseURL() string {
+	urls := d.DatabaseURLs()
+	if len(urls) == 0 {
+		return ""
+	}
+	return urls[0]
+}
+
+// DatabaseURLs returns one URL per externally reachable (address, port) pair for the
+// database's external-access Service, to reach the database from outside the Kubernetes
+// cluster. An empty slice means that the database is not reachable outside the Kubernetes
+// cluster.
+//
+// On a dual-stack cluster this can return one URL per IP family: a LoadBalancer Service
+// reports one Ingress entry per family it was assigned, and externalEndpoints picks one
+// NodePort address per family found among the candidate Nodes. What is still not
+// implemented: requesting a particular ipFamilyPolicy/ipFamilies on the generated Service
+// itself (that is decided by the cluster's default, not by this method), a
+// Spec.Network.IPFamilyPolicy field to let a user pin it, and preferring status.podIPs in
+// inspectPods — this method only reports families the Service/Nodes already happen to carry.
+func (d *Deployment) DatabaseURLs() []string {
+	endpoints := d.externalEndpoints()
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	scheme := "https"
+	if !d.GetSpec().IsSecure() {
+		scheme = "http"
+	}
+	urls := make([]string, 0, len(endpoints))
+	for _, ep := range endpoints {
+		urls = append(urls, fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(ep.host, strconv.Itoa(ep.port))))
+	}
+	return urls
+}
+
+// DatabaseURLsByFamily groups DatabaseURLs by the IP family of their resolved address.
+// An endpoint whose address is a DNS name rather than a literal IP (a LoadBalancer
+// Ingress.Hostname) cannot be classified without a DNS lookup this method does not
+// perform, and is omitted here even though DatabaseURLs still includes it.
+func (d *Deployment) DatabaseURLsByFamily() map[v1.IPFamily][]string {
+	endpoints := d.externalEndpoints()
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	scheme := "https"
+	if !d.GetSpec().IsSecure() {
+		scheme = "http"
+	}
+	result := make(map[v1.IPFamily][]string)
+	for _, ep := range endpoints {
+		family := ipFamilyOf(ep.host)
+		if family == "" {
+			continue
+		}
+		result[family] = append(result[family], fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(ep.host, strconv.Itoa(ep.port))))
+	}
+	return result
+}
+
+// externalEndpoints resolves the externally reachable (address, port) pairs for the
+// database's external-access Service. An empty slice means the database is not reachable
+// from outside the Kubernetes cluster (or the Service does not exist yet).
+func (d *Deployment) externalEndpoints() []hostPort {
 	eaSvcName := k8sutil.CreateDatabaseExternalAccessServiceName(d.Name())
 	ns := d.apiObject.Namespace
 	svc, err := d.deps.KubeCli.CoreV1().Services(ns).Get(eaSvcName, metav1.GetOptions{})
 	if err != nil {
-		return ""
+		return nil
 	}
-	host := ""
+
+	var endpoints []hostPort
 	switch svc.Spec.Type {
 	case v1.ServiceTypeLoadBalancer:
 		for _, i := range svc.Status.LoadBalancer.Ingress {
+			host := i.IP
 			if i.Hostname != "" {
 				host = i.Hostname
-			} else {
-				host = i.IP
 			}
-			break
+			if host != "" {
+				endpoints = append(endpoints, hostPort{host: host, port: k8sutil.ArangoPort})
+			}
 		}
 	case v1.ServiceTypeNodePort:
-		// TODO
+		eps, err := d.nodePortEndpoints(svc)
+		if err != nil {
+			d.deps.Log.Debug().Err(err).Msg("Failed to determine a reachable NodePort address")
+			return nil
+		}
+		endpoints = append(endpoints, eps...)
 	}
-	if host == "" {
+	return endpoints
+}
+
+// hostPort is a resolved (address, port) pair for an external-access endpoint.
+type hostPort struct {
+	host string
+	port int
+}
+
+// ipFamilyOf classifies host as IPv4 or IPv6 when it is a literal IP address, or "" when it
+// is a DNS name (e.g. a LoadBalancer Ingress.Hostname).
+func ipFamilyOf(host string) v1.IPFamily {
+	ip := net.ParseIP(host)
+	switch {
+	case ip == nil:
 		return ""
+	case ip.To4() != nil:
+		return v1.IPv4Protocol
+	default:
+		return v1.IPv6Protocol
 	}
-	scheme := "https"
-	if !d.GetSpec().IsSecure() {
-		scheme = "http"
+}
+
+// nodePortHostPriority ranks node address types by how likely they are to be reachable
+// from outside the Kubernetes cluster, mirroring the preference order cloud-provider
+// LoadBalancer implementations use when picking an address to report.
+var nodePortHostPriority = map[v1.NodeAddressType]int{
+	v1.NodeExternalIP:  0,
+	v1.NodeExternalDNS: 1,
+	v1.NodeInternalIP:  2,
+}
+
+// nodePortEndpoints determines, per IP family found among the matching Nodes' addresses, a
+// stable, reachable (address, NodePort) pair for svc, which must be of type NodePort. On a
+// dual-stack cluster this can return one IPv4 and one IPv6 endpoint; on a single-stack
+// cluster, one. It returns nil, nil when no suitable node/port could be found.
+func (d *Deployment) nodePortEndpoints(svc *v1.Service) ([]hostPort, error) {
+	var nodePort int
+	for _, p := range svc.Spec.Ports {
+		if p.Port == int32(k8sutil.ArangoPort) {
+			nodePort = int(p.NodePort)
+			break
+		}
+	}
+	if nodePort == 0 {
+		return nil, nil
 	}
-	return fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(host, strconv.Itoa(k8sutil.ArangoPort)))
+
+	nodes, err := d.deps.KubeCli.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, maskAny(err)
+	}
+
+	// The external-access Service always targets the Coordinators group, so only nodes
+	// that group is actually scheduled onto are reachable through its NodePort.
+	selector := labels.SelectorFromSet(d.GetSpec().Coordinators.GetNodeSelector())
+
+	type candidate struct {
+		address  string
+		priority int
+	}
+	var candidates []candidate
+	for _, node := range nodes.Items {
+		if !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		for _, addr := range node.Status.Addresses {
+			priority, known := nodePortHostPriority[addr.Type]
+			if !known {
+				continue
+			}
+			candidates = append(candidates, candidate{address: addr.Address, priority: priority})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].priority != candidates[j].priority {
+			return candidates[i].priority < candidates[j].priority
+		}
+		return candidates[i].address < candidates[j].address
+	})
+
+	// Candidates are sorted best-first; keep only the best candidate for each IP family,
+	// plus the best unclassified (DNS name) candidate if no family was ever found.
+	bestByFamily := make(map[v1.IPFamily]candidate)
+	var bestUnclassified *candidate
+	for _, c := range candidates {
+		family := ipFamilyOf(c.address)
+		if family == "" {
+			if bestUnclassified == nil {
+				cc := c
+				bestUnclassified = &cc
+			}
+			continue
+		}
+		if _, found := bestByFamily[family]; !found {
+			bestByFamily[family] = c
+		}
+	}
+
+	var endpoints []hostPort
+	for _, family := range []v1.IPFamily{v1.IPv4Protocol, v1.IPv6Protocol} {
+		if c, found := bestByFamily[family]; found {
+			endpoints = append(endpoints, hostPort{host: c.address, port: nodePort})
+		}
+	}
+	if len(endpoints) == 0 && bestUnclassified != nil {
+		endpoints = append(endpoints, hostPort{host: bestUnclassified.address, port: nodePort})
+	}
+	return endpoints, nil
 }
 
 // DatabaseVersion returns the version used by the deployment
@@ -199,7 +376,8 @@ func (d *Deployment) DatabaseVersion() (string, string) {
 	return string(info.ArangoDBVersion), license
 }
 
-// Members returns all members of the deployment by role.
+// Members returns all members of the deployment by role, merging in the members
+// contributed by every remote cluster this deployment spans (see remoteMembers).
 func (d *Deployment) Members() map[api.ServerGroup][]server.Member {
 	result := make(map[api.ServerGroup][]server.Member)
 	status, _ := d.GetStatus()
@@ -217,5 +395,8 @@ func (d *Deployment) Members() map[api.ServerGroup][]server.Member {
 		}
 		return nil
 	})
+	for group, members := range d.remoteMembers() {
+		result[group] = append(result[group], members...)
+	}
 	return result
 }
@@ -0,0 +1,259 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Package federation lets a single operator instance orchestrate one logical ArangoDB
+// cluster spread over several member Kubernetes clusters: one agency/coordinator/dbserver
+// group per member cluster, joined into a single ArangoDB cluster over a WAN.
+package federation
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1alpha"
+	fedapi "github.com/arangodb/kube-arangodb/pkg/apis/federation/v1alpha"
+	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+// Dependencies holds the per-member-cluster clients needed to reconcile one member of a
+// FederatedArangoDeployment, mirroring pkg/deployment.Dependencies but scoped to a single
+// member cluster rather than the local cluster.
+type Dependencies struct {
+	Log           zerolog.Logger
+	KubeCli       kubernetes.Interface
+	DatabaseCRCli versioned.Interface
+}
+
+// Config holds configuration settings for the Controller.
+type Config struct {
+	ServiceAccount string
+}
+
+// Controller orchestrates a single FederatedArangoDeployment across its member clusters.
+type Controller struct {
+	config    Config
+	localDeps Dependencies
+
+	apiObject *fedapi.FederatedArangoDeployment
+
+	mu      sync.RWMutex
+	members map[string]Dependencies // clusterName -> Dependencies for that member cluster
+}
+
+// New creates a Controller for the given FederatedArangoDeployment.
+// It builds a Dependencies for every member cluster declared in the spec by loading the
+// kubeconfig referenced by KubeconfigSecretName (or falling back to localDeps for a
+// member whose KubeconfigSecretName is empty, meaning "the cluster the operator runs in").
+func New(config Config, localDeps Dependencies, apiObject *fedapi.FederatedArangoDeployment) (*Controller, error) {
+	c := &Controller{
+		config:    config,
+		localDeps: localDeps,
+		apiObject: apiObject,
+		members:   make(map[string]Dependencies),
+	}
+
+	for _, m := range apiObject.Spec.Members {
+		deps, err := c.buildMemberDependencies(m)
+		if err != nil {
+			return nil, maskAny(fmt.Errorf("failed to connect to member cluster '%s': %v", m.ClusterName, err))
+		}
+		c.members[m.ClusterName] = deps
+	}
+
+	return c, nil
+}
+
+// buildMemberDependencies creates a Dependencies for a single member cluster, either from
+// a kubeconfig Secret or (when none is given) from the dependencies of the local cluster.
+func (c *Controller) buildMemberDependencies(m fedapi.FederationMemberSpec) (Dependencies, error) {
+	if m.KubeconfigSecretName == "" {
+		return c.localDeps, nil
+	}
+
+	secret, err := c.localDeps.KubeCli.CoreV1().Secrets(c.apiObject.Namespace).Get(m.KubeconfigSecretName, metav1.GetOptions{})
+	if err != nil {
+		return Dependencies{}, maskAny(err)
+	}
+	kubeconfig, found := secret.Data["kubeconfig"]
+	if !found {
+		return Dependencies{}, maskAny(fmt.Errorf("secret '%s' has no 'kubeconfig' key", m.KubeconfigSecretName))
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return Dependencies{}, maskAny(err)
+	}
+
+	kubeCli, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return Dependencies{}, maskAny(err)
+	}
+	dbCli, err := versioned.NewForConfig(restConfig)
+	if err != nil {
+		return Dependencies{}, maskAny(err)
+	}
+
+	return Dependencies{
+		Log:           c.localDeps.Log.With().Str("cluster", m.ClusterName).Logger(),
+		KubeCli:       kubeCli,
+		DatabaseCRCli: dbCli,
+	}, nil
+}
+
+// Reconcile fans out a child ArangoDeployment to every member cluster, mirrors the TLS CA
+// secret between them (see mirrorJoinSecrets), and aggregates their statuses back into the
+// FederatedArangoDeployment status.
+func (c *Controller) Reconcile() error {
+	if err := c.mirrorJoinSecrets(); err != nil {
+		return maskAny(err)
+	}
+
+	statuses := make([]fedapi.FederationMemberStatus, 0, len(c.apiObject.Spec.Members))
+	for _, m := range c.apiObject.Spec.Members {
+		deps, found := c.members[m.ClusterName]
+		if !found {
+			statuses = append(statuses, fedapi.FederationMemberStatus{
+				ClusterName: m.ClusterName,
+				Phase:       "Unknown",
+				Reason:      "no connection to member cluster",
+			})
+			continue
+		}
+
+		status, err := c.reconcileMember(deps, m)
+		if err != nil {
+			statuses = append(statuses, fedapi.FederationMemberStatus{
+				ClusterName: m.ClusterName,
+				Phase:       "Failed",
+				Reason:      err.Error(),
+			})
+			continue
+		}
+		statuses = append(statuses, status)
+	}
+
+	c.apiObject.Status.Members = statuses
+	return nil
+}
+
+// reconcileMember ensures a child ArangoDeployment exists in the member cluster and
+// returns its aggregated status. The child is created once from m and never updated
+// afterwards here; resizing an existing member is left to whoever edits the child
+// ArangoDeployment directly (mirroring how pkg/deployment's own controller owns it once
+// it exists).
+func (c *Controller) reconcileMember(deps Dependencies, m fedapi.FederationMemberSpec) (fedapi.FederationMemberStatus, error) {
+	cli := deps.DatabaseCRCli.DatabaseV1alpha().ArangoDeployments(c.apiObject.Namespace)
+
+	existing, err := cli.Get(c.apiObject.Name, metav1.GetOptions{})
+	if err != nil {
+		if !k8sutil.IsNotFound(err) {
+			return fedapi.FederationMemberStatus{}, maskAny(err)
+		}
+		existing, err = cli.Create(c.childDeploymentFor(m))
+		if err != nil {
+			return fedapi.FederationMemberStatus{}, maskAny(err)
+		}
+	}
+
+	return fedapi.FederationMemberStatus{
+		ClusterName: m.ClusterName,
+		Phase:       memberPhase(existing.Status.State),
+	}, nil
+}
+
+// childDeploymentFor builds the desired child ArangoDeployment for member cluster m, named
+// and namespaced after the owning FederatedArangoDeployment.
+func (c *Controller) childDeploymentFor(m fedapi.FederationMemberSpec) *api.ArangoDeployment {
+	return &api.ArangoDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.apiObject.Name,
+			Namespace: c.apiObject.Namespace,
+		},
+		Spec: api.DeploymentSpec{
+			Agents:       api.ServerGroupSpec{Count: m.Agents},
+			DBServers:    api.ServerGroupSpec{Count: m.DBServers},
+			Coordinators: api.ServerGroupSpec{Count: m.Coordinators},
+		},
+	}
+}
+
+// memberPhase maps a child ArangoDeployment's status.State onto the coarser Phase reported
+// on the FederatedArangoDeployment. An empty/unrecognized state (not yet reconciled by the
+// member cluster's own operator) is reported as "Pending" rather than silently as "Running".
+func memberPhase(state api.DeploymentState) string {
+	switch state {
+	case api.DeploymentStateRunning:
+		return "Running"
+	case api.DeploymentStateFailed:
+		return "Failed"
+	default:
+		return "Pending"
+	}
+}
+
+// mirrorJoinSecrets copies the TLS CA Secret used by the first member cluster (the "seed"
+// cluster) into every other member cluster. No other Secret is mirrored: a join token such
+// as the JWT secret is not copied between member clusters.
+func (c *Controller) mirrorJoinSecrets() error {
+	if len(c.apiObject.Spec.Members) == 0 {
+		return nil
+	}
+	seed := c.apiObject.Spec.Members[0]
+	seedDeps, found := c.members[seed.ClusterName]
+	if !found {
+		return nil
+	}
+
+	secretName := k8sutil.CreateTLSCASecretName(c.apiObject.Name)
+	caSecret, err := seedDeps.KubeCli.CoreV1().Secrets(c.apiObject.Namespace).Get(secretName, metav1.GetOptions{})
+	if err != nil {
+		if k8sutil.IsNotFound(err) {
+			return nil
+		}
+		return maskAny(err)
+	}
+
+	for _, m := range c.apiObject.Spec.Members[1:] {
+		deps, found := c.members[m.ClusterName]
+		if !found {
+			continue
+		}
+		mirrored := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      caSecret.Name,
+				Namespace: c.apiObject.Namespace,
+			},
+			Data: caSecret.Data,
+			Type: caSecret.Type,
+		}
+		if _, err := deps.KubeCli.CoreV1().Secrets(c.apiObject.Namespace).Create(mirrored); err != nil && !k8sutil.IsAlreadyExists(err) {
+			return maskAny(fmt.Errorf("failed to mirror CA secret into cluster '%s': %v", m.ClusterName, err))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1alpha"
+)
+
+// fakeMember is a minimal Member implementation for contract-testing the handlers in this
+// package without a real pkg/deployment.Deployment.
+type fakeMember struct {
+	id      string
+	podName string
+	ready   bool
+}
+
+func (m fakeMember) ID() string      { return m.id }
+func (m fakeMember) PodName() string { return m.podName }
+func (m fakeMember) PVCName() string { return m.id + "-pvc" }
+func (m fakeMember) PVName() string  { return m.id + "-pv" }
+func (m fakeMember) Ready() bool     { return m.ready }
+
+// fakeDeployment is a minimal Deployment implementation for contract-testing this package's
+// handlers (NewDeploymentInfo(Details), quorumFailures) against the Deployment interface,
+// without depending on pkg/deployment or any of its Kubernetes clients.
+//
+// This does not replace handler-level (gin) tests: the Server type and its dependencies
+// (s.deps.Operators) are not defined anywhere in this tree, so handleDeploymentHealthz and
+// friends cannot be invoked end-to-end here.
+type fakeDeployment struct {
+	name    string
+	ns      string
+	members map[api.ServerGroup][]Member
+}
+
+func (d *fakeDeployment) Name() string                      { return d.name }
+func (d *fakeDeployment) Namespace() string                 { return d.ns }
+func (d *fakeDeployment) Mode() api.DeploymentMode           { return api.DeploymentModeCluster }
+func (d *fakeDeployment) Environment() api.Environment       { return api.EnvironmentProduction }
+func (d *fakeDeployment) StorageClasses() []string           { return nil }
+func (d *fakeDeployment) DatabaseURL() string                { return "" }
+func (d *fakeDeployment) DatabaseURLs() []string             { return nil }
+func (d *fakeDeployment) DatabaseVersion() (string, string)  { return "3.9.0", "community" }
+func (d *fakeDeployment) Reconciled() bool                   { return true }
+func (d *fakeDeployment) Members() map[api.ServerGroup][]Member { return d.members }
+
+func (d *fakeDeployment) Subscribe(ctx context.Context) <-chan DeploymentInfoDetails {
+	ch := make(chan DeploymentInfoDetails)
+	close(ch)
+	return ch
+}
+
+func (d *fakeDeployment) QueryMembers(q MemberQuery) ([]Member, error) {
+	var result []Member
+	for _, list := range d.members {
+		result = append(result, list...)
+	}
+	return result, nil
+}
+
+func (d *fakeDeployment) PodCount() int {
+	count := 0
+	for _, list := range d.members {
+		count += len(list)
+	}
+	return count
+}
+
+func (d *fakeDeployment) ReadyPodCount() int {
+	count := 0
+	for _, list := range d.members {
+		for _, m := range list {
+			if m.(fakeMember).ready {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func (d *fakeDeployment) VolumeCount() int      { return d.PodCount() }
+func (d *fakeDeployment) ReadyVolumeCount() int { return d.ReadyPodCount() }
+
+func TestNewDeploymentInfoDetailsJoinsMembers(t *testing.T) {
+	d := &fakeDeployment{
+		name: "my-deployment",
+		ns:   "default",
+		members: map[api.ServerGroup][]Member{
+			api.ServerGroupCoordinators: {
+				fakeMember{id: "crdn-1", podName: "pod-crdn-1", ready: true},
+			},
+		},
+	}
+
+	details := newDeploymentInfoDetails(d)
+
+	if details.Name != "my-deployment" || details.Namespace != "default" {
+		t.Fatalf("unexpected identity in DeploymentInfoDetails: %+v", details.DeploymentInfo)
+	}
+	if len(details.MemberGroups) != 1 || details.MemberGroups[0].Group != "Coordinator" {
+		t.Fatalf("expected one Coordinator member group, got %+v", details.MemberGroups)
+	}
+	if got := details.MemberGroups[0].Members[0].PodName; got != "pod-crdn-1" {
+		t.Fatalf("expected pod name to be carried through, got %q", got)
+	}
+}
+
+func TestQuorumFailuresAgencyMajorityLost(t *testing.T) {
+	members := map[api.ServerGroup][]Member{
+		api.ServerGroupAgents: {
+			fakeMember{id: "agnt-1", ready: true},
+			fakeMember{id: "agnt-2", ready: false},
+			fakeMember{id: "agnt-3", ready: false},
+		},
+	}
+
+	failures := quorumFailures(members)
+
+	found := false
+	for _, f := range failures {
+		if f.Group == "agent" && f.MemberID == "" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected an agency-quorum-lost failure, got %+v", failures)
+	}
+}
+
+func TestQuorumFailuresHealthyCluster(t *testing.T) {
+	members := map[api.ServerGroup][]Member{
+		api.ServerGroupAgents: {
+			fakeMember{id: "agnt-1", ready: true},
+			fakeMember{id: "agnt-2", ready: true},
+			fakeMember{id: "agnt-3", ready: true},
+		},
+		api.ServerGroupCoordinators: {
+			fakeMember{id: "crdn-1", ready: true},
+		},
+	}
+
+	if failures := quorumFailures(members); len(failures) != 0 {
+		t.Fatalf("expected no quorum failures, got %+v", failures)
+	}
+}
+
+// TestQuorumFailuresToleratesOneUnreadyAgent asserts that a quorum satisfied at the group
+// level still reports the individual not-ready member: "healthy" only means quorum holds,
+// not that every member is up.
+func TestQuorumFailuresToleratesOneUnreadyAgent(t *testing.T) {
+	members := map[api.ServerGroup][]Member{
+		api.ServerGroupAgents: {
+			fakeMember{id: "agnt-1", ready: true},
+			fakeMember{id: "agnt-2", ready: true},
+			fakeMember{id: "agnt-3", ready: false},
+		},
+	}
+
+	failures := quorumFailures(members)
+
+	for _, f := range failures {
+		if f.Group == "agent" && f.MemberID == "" {
+			t.Fatalf("did not expect an agency-quorum-lost failure, got %+v", failures)
+		}
+	}
+	if len(failures) != 1 || failures[0].MemberID != "agnt-3" {
+		t.Fatalf("expected exactly one per-member failure for agnt-3, got %+v", failures)
+	}
+}
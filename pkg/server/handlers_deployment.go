@@ -23,15 +23,25 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	v1 "k8s.io/api/core/v1"
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1alpha"
 )
 
+// watchHeartbeatInterval is how often a SSE heartbeat comment is sent on a watch
+// connection, whether or not events are flowing, to keep intermediate proxies from timing
+// it out while it is idle.
+const watchHeartbeatInterval = 15 * time.Second
+
 // Deployment is the API implemented by an ArangoDeployment.
 type Deployment interface {
 	Name() string
@@ -43,9 +53,33 @@ type Deployment interface {
 	VolumeCount() int
 	ReadyVolumeCount() int
 	StorageClasses() []string
+	// DatabaseURL is kept for backwards compatibility; it returns the first entry of
+	// DatabaseURLs, or "" when the deployment is not reachable from outside the cluster.
 	DatabaseURL() string
+	// DatabaseURLs returns one URL per externally reachable address of the deployment
+	// (dual-stack aware: on a dual-stack cluster this is typically one per IP family).
+	DatabaseURLs() []string
 	DatabaseVersion() (string, string)
 	Members() map[api.ServerGroup][]Member
+	// Subscribe returns a channel that receives a new DeploymentInfoDetails snapshot
+	// every time the deployment's observed state changes. The channel is closed when
+	// ctx is cancelled.
+	Subscribe(ctx context.Context) <-chan DeploymentInfoDetails
+	// Reconciled returns true once the operator has synced this deployment at least once.
+	Reconciled() bool
+	// QueryMembers returns the members matching q, pre-joined with their pod/PVC status.
+	QueryMembers(q MemberQuery) ([]Member, error)
+}
+
+// MemberQuery filters the members returned by Deployment.QueryMembers. A nil pointer or
+// empty slice/string means "don't filter on this field".
+type MemberQuery struct {
+	Groups            []api.ServerGroup
+	Ready             *bool
+	PodPhases         []v1.PodPhase
+	PVCBound          *bool
+	ImageID           string
+	VersionConstraint string // e.g. ">=3.5.0"
 }
 
 // Member is the API implemented by a member of an ArangoDeployment.
@@ -54,14 +88,16 @@ type Member interface {
 	PodName() string
 	PVCName() string
 	PVName() string
+	// Ready returns true if the member's Ready condition is set.
+	Ready() bool
 }
 
 // DeploymentOperator is the API implemented by the deployment operator.
 type DeploymentOperator interface {
 	// GetDeployments returns basic information for all deployments managed by the operator
 	GetDeployments() ([]Deployment, error)
-	// GetDeployment returns detailed information for a deployment, managed by the operator, with given name
-	GetDeployment(name string) (Deployment, error)
+	// GetDeployment returns detailed information for the deployment with given namespace and name, managed by the operator
+	GetDeployment(namespace, name string) (Deployment, error)
 }
 
 // DeploymentInfo is the information returned per deployment.
@@ -76,10 +112,18 @@ type DeploymentInfo struct {
 	ReadyVolumeCount int                `json:"ready_volume_count"`
 	StorageClasses   []string           `json:"storage_classes"`
 	DatabaseURL      string             `json:"database_url"`
+	DatabaseURLs     []string           `json:"database_urls"`
 	DatabaseVersion  string             `json:"database_version"`
 	DatabaseLicense  string             `json:"database_license"`
 }
 
+// NewDeploymentInfo initializes a DeploymentInfo for the given Deployment.
+// It is exported so pkg/deployment can reuse this struct as the CloudEvents data payload
+// for deployment-level lifecycle transitions.
+func NewDeploymentInfo(d Deployment) DeploymentInfo {
+	return newDeploymentInfo(d)
+}
+
 // newDeploymentInfo initializes a DeploymentInfo for the given Deployment.
 func newDeploymentInfo(d Deployment) DeploymentInfo {
 	version, license := d.DatabaseVersion()
@@ -94,6 +138,7 @@ func newDeploymentInfo(d Deployment) DeploymentInfo {
 		ReadyVolumeCount: d.ReadyVolumeCount(),
 		StorageClasses:   d.StorageClasses(),
 		DatabaseURL:      d.DatabaseURL(),
+		DatabaseURLs:     d.DatabaseURLs(),
 		DatabaseVersion:  version,
 		DatabaseLicense:  license,
 	}
@@ -129,6 +174,13 @@ type DeploymentInfoDetails struct {
 	MemberGroups []MemberGroupInfo `json:"member_groups"`
 }
 
+// NewDeploymentInfoDetails initializes a DeploymentInfoDetails for the given Deployment.
+// It is exported so pkg/deployment can build the payload it broadcasts to watch
+// subscribers without duplicating the member-group join logic below.
+func NewDeploymentInfoDetails(d Deployment) DeploymentInfoDetails {
+	return newDeploymentInfoDetails(d)
+}
+
 // newDeploymentInfoDetails initializes a DeploymentInfoDetails for the given Deployment.
 func newDeploymentInfoDetails(d Deployment) DeploymentInfoDetails {
 	result := DeploymentInfoDetails{
@@ -169,11 +221,11 @@ func (s *Server) handleGetDeployments(c *gin.Context) {
 	}
 }
 
-// Handle a GET /api/deployment/:name request
+// Handle a GET /api/deployment/:ns/:name request
 func (s *Server) handleGetDeploymentDetails(c *gin.Context) {
 	if do := s.deps.Operators.DeploymentOperator(); do != nil {
 		// Fetch deployments
-		depl, err := do.GetDeployment(c.Params.ByName("name"))
+		depl, err := do.GetDeployment(c.Params.ByName("ns"), c.Params.ByName("name"))
 		if err != nil {
 			sendError(c, err)
 		} else {
@@ -182,3 +234,142 @@ func (s *Server) handleGetDeploymentDetails(c *gin.Context) {
 		}
 	}
 }
+
+// Handle a GET /api/deployment/watch request.
+// Upgrades to a Server-Sent Events stream that pushes a new DeploymentInfo for every
+// deployment managed by the operator whenever any of them changes.
+func (s *Server) handleWatchDeployments(c *gin.Context) {
+	do := s.deps.Operators.DeploymentOperator()
+	if do == nil {
+		return
+	}
+	depls, err := do.GetDeployments()
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	streamSSE(c, func(ctx context.Context, send func(eventID string, v interface{})) {
+		merged := make(chan DeploymentInfo)
+		for _, d := range depls {
+			go forwardDeploymentInfo(ctx, d, merged)
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case info := <-merged:
+				send(fmt.Sprintf("%s/%d", info.Name, time.Now().UnixNano()), info)
+			}
+		}
+	})
+}
+
+// Handle a GET /api/deployment/:ns/:name/watch request.
+// Upgrades to a Server-Sent Events stream that pushes a new DeploymentInfoDetails every
+// time the named deployment's state changes.
+func (s *Server) handleWatchDeploymentDetails(c *gin.Context) {
+	do := s.deps.Operators.DeploymentOperator()
+	if do == nil {
+		return
+	}
+	depl, err := do.GetDeployment(c.Params.ByName("ns"), c.Params.ByName("name"))
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	streamSSE(c, func(ctx context.Context, send func(eventID string, v interface{})) {
+		sub := depl.Subscribe(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case info, ok := <-sub:
+				if !ok {
+					return
+				}
+				send(fmt.Sprintf("%d", time.Now().UnixNano()), info)
+			}
+		}
+	})
+}
+
+// forwardDeploymentInfo re-emits newDeploymentInfo(d) onto out every time d changes,
+// until ctx is cancelled.
+func forwardDeploymentInfo(ctx context.Context, d Deployment, out chan<- DeploymentInfo) {
+	sub := d.Subscribe(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case details, ok := <-sub:
+			if !ok {
+				return
+			}
+			select {
+			case out <- details.DeploymentInfo:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// streamSSE upgrades c to a text/event-stream response and calls run with a send function
+// that writes a single SSE event (flushing immediately), and writes a heartbeat comment
+// every watchHeartbeatInterval whether or not run has produced events. run must return
+// when its ctx is cancelled (the client disconnected).
+//
+// Each event is written with a real "id:" line carrying eventID, so a client that
+// reconnects with a Last-Event-ID header at least knows which update it last saw. This
+// handler keeps no event history, so it cannot replay anything that happened while the
+// client was disconnected; resuming without missing transitions is out of scope here.
+func streamSSE(c *gin.Context, run func(ctx context.Context, send func(eventID string, v interface{}))) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		sendError(c, fmt.Errorf("streaming not supported"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	events := make(chan struct {
+		id string
+		v  interface{}
+	})
+	go run(ctx, func(eventID string, v interface{}) {
+		select {
+		case events <- struct {
+			id string
+			v  interface{}
+		}{eventID, v}:
+		case <-ctx.Done():
+		}
+	})
+
+	heartbeat := time.NewTicker(watchHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-events:
+			data, err := json.Marshal(ev.v)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(c.Writer, "id: %s\ndata: %s\n\n", ev.id, data)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,88 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Federation is the API implemented by a federated ArangoDeployment: a single logical
+// ArangoDB cluster spread over several member Kubernetes clusters.
+type Federation interface {
+	Name() string
+	// Members returns, per member cluster name, the DeploymentInfo of that cluster's
+	// child ArangoDeployment, or nil when the child is not (yet) reachable.
+	Members() map[string]*DeploymentInfo
+}
+
+// FederationOperator is the API implemented by the federation operator.
+type FederationOperator interface {
+	// GetFederations returns basic information for all federations managed by the operator
+	GetFederations() ([]Federation, error)
+	// GetFederation returns detailed information for the federation with the given name
+	GetFederation(name string) (Federation, error)
+}
+
+// FederationInfo is the information returned per federation.
+type FederationInfo struct {
+	Name    string                     `json:"name"`
+	Members map[string]*DeploymentInfo `json:"members"`
+}
+
+// newFederationInfo initializes a FederationInfo for the given Federation.
+func newFederationInfo(f Federation) FederationInfo {
+	return FederationInfo{
+		Name:    f.Name(),
+		Members: f.Members(),
+	}
+}
+
+// Handle a GET /api/federation request
+func (s *Server) handleGetFederations(c *gin.Context) {
+	if fo := s.deps.Operators.FederationOperator(); fo != nil {
+		feds, err := fo.GetFederations()
+		if err != nil {
+			sendError(c, err)
+		} else {
+			result := make([]FederationInfo, len(feds))
+			for i, f := range feds {
+				result[i] = newFederationInfo(f)
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"federations": result,
+			})
+		}
+	}
+}
+
+// Handle a GET /api/federation/:name request
+func (s *Server) handleGetFederationDetails(c *gin.Context) {
+	if fo := s.deps.Operators.FederationOperator(); fo != nil {
+		fed, err := fo.GetFederation(c.Params.ByName("name"))
+		if err != nil {
+			sendError(c, err)
+		} else {
+			c.JSON(http.StatusOK, newFederationInfo(fed))
+		}
+	}
+}
@@ -0,0 +1,142 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1alpha"
+)
+
+// HealthCheckFailure describes a single failed health/readiness subcheck. Group and
+// MemberID are omitted for checks that are not specific to a single server group/member.
+type HealthCheckFailure struct {
+	Group     string `json:"group,omitempty"`
+	MemberID  string `json:"member_id,omitempty"`
+	Condition string `json:"condition"`
+}
+
+// HealthCheckResult is the structured body returned by handleDeploymentHealthz and
+// handleDeploymentReadyz.
+type HealthCheckResult struct {
+	Healthy  bool                 `json:"healthy"`
+	Failures []HealthCheckFailure `json:"failures,omitempty"`
+}
+
+// Handle a GET /api/deployment/:ns/:name/healthz request.
+// Returns 200 when the named ArangoDeployment exists and has been reconciled by the
+// operator at least once. Intended to back a liveness probe, not a readiness probe: a
+// deployment that is reconciled but unhealthy (e.g. quorum lost) still answers healthy here.
+func (s *Server) handleDeploymentHealthz(c *gin.Context) {
+	do := s.deps.Operators.DeploymentOperator()
+	if do == nil {
+		return
+	}
+	depl, err := do.GetDeployment(c.Params.ByName("ns"), c.Params.ByName("name"))
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, HealthCheckResult{
+			Failures: []HealthCheckFailure{{Condition: "deployment not found"}},
+		})
+		return
+	}
+	if !depl.Reconciled() {
+		c.JSON(http.StatusServiceUnavailable, HealthCheckResult{
+			Failures: []HealthCheckFailure{{Condition: "not yet reconciled"}},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, HealthCheckResult{Healthy: true})
+}
+
+// Handle a GET /api/deployment/:ns/:name/readyz request.
+// Returns 200 only when every pod and volume is ready/bound and every server group that
+// needs a quorum to serve traffic has one. Intended to back a readiness probe of a sidecar
+// or the health check of an external load balancer in front of the deployment.
+func (s *Server) handleDeploymentReadyz(c *gin.Context) {
+	do := s.deps.Operators.DeploymentOperator()
+	if do == nil {
+		return
+	}
+	depl, err := do.GetDeployment(c.Params.ByName("ns"), c.Params.ByName("name"))
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, HealthCheckResult{
+			Failures: []HealthCheckFailure{{Condition: "deployment not found"}},
+		})
+		return
+	}
+
+	failures := quorumFailures(depl.Members())
+
+	if volCount := depl.VolumeCount(); depl.ReadyVolumeCount() < volCount {
+		failures = append(failures, HealthCheckFailure{
+			Condition: fmt.Sprintf("only %d/%d volumes bound", depl.ReadyVolumeCount(), volCount),
+		})
+	}
+
+	if len(failures) > 0 {
+		c.JSON(http.StatusServiceUnavailable, HealthCheckResult{Failures: failures})
+		return
+	}
+	c.JSON(http.StatusOK, HealthCheckResult{Healthy: true})
+}
+
+// quorumFailures checks, per server group, that enough of its members are ready to serve
+// traffic: a strict majority of agents, at least one coordinator, or the single server.
+// It also reports each individual member whose Ready condition is not set.
+func quorumFailures(members map[api.ServerGroup][]Member) []HealthCheckFailure {
+	var failures []HealthCheckFailure
+	for group, list := range members {
+		role := group.AsRole()
+		ready := 0
+		for _, m := range list {
+			if m.Ready() {
+				ready++
+				continue
+			}
+			failures = append(failures, HealthCheckFailure{
+				Group:     role,
+				MemberID:  m.ID(),
+				Condition: "pod not ready",
+			})
+		}
+		switch role {
+		case "agent":
+			if ready <= len(list)/2 {
+				failures = append(failures, HealthCheckFailure{
+					Group:     role,
+					Condition: fmt.Sprintf("agency quorum lost: %d/%d agents ready", ready, len(list)),
+				})
+			}
+		case "coordinator":
+			if ready == 0 {
+				failures = append(failures, HealthCheckFailure{Group: role, Condition: "no coordinator ready"})
+			}
+		case "single":
+			if ready == 0 {
+				failures = append(failures, HealthCheckFailure{Group: role, Condition: "single server not ready"})
+			}
+		}
+	}
+	return failures
+}
@@ -0,0 +1,122 @@
+//
+// DISCLAIMER
+//
+// Copyright 2018 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	v1 "k8s.io/api/core/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1alpha"
+)
+
+// groupsByQueryName maps the `group` query parameter values accepted by
+// handleQueryMembers to their api.ServerGroup. Kept independent of ServerGroup.AsRole so
+// the REST contract does not change if the internal role naming ever does.
+var groupsByQueryName = map[string]api.ServerGroup{
+	"single":       api.ServerGroupSingle,
+	"agents":       api.ServerGroupAgents,
+	"dbservers":    api.ServerGroupDBServers,
+	"coordinators": api.ServerGroupCoordinators,
+	"syncmasters":  api.ServerGroupSyncMasters,
+	"syncworkers":  api.ServerGroupSyncWorkers,
+}
+
+// Handle a GET /api/deployment/:ns/:name/members request.
+//
+// Supported query parameters (all optional, combined with AND):
+//
+//	group=dbservers       (repeatable)
+//	ready=true|false
+//	pod_phase=Running     (repeatable)
+//	pvc_bound=true|false
+//	image_id=...
+//	version=>=3.5.0
+func (s *Server) handleQueryMembers(c *gin.Context) {
+	do := s.deps.Operators.DeploymentOperator()
+	if do == nil {
+		return
+	}
+	depl, err := do.GetDeployment(c.Params.ByName("ns"), c.Params.ByName("name"))
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	q, err := parseMemberQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	members, err := depl.QueryMembers(q)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	result := make([]MemberInfo, len(members))
+	for i, m := range members {
+		result[i] = newMemberInfo(m)
+	}
+	c.JSON(http.StatusOK, gin.H{"members": result})
+}
+
+// parseMemberQuery builds a MemberQuery from c's query parameters.
+func parseMemberQuery(c *gin.Context) (MemberQuery, error) {
+	var q MemberQuery
+
+	for _, name := range c.QueryArray("group") {
+		group, found := groupsByQueryName[name]
+		if !found {
+			return MemberQuery{}, fmt.Errorf("unknown group %q", name)
+		}
+		q.Groups = append(q.Groups, group)
+	}
+
+	if v := c.Query("ready"); v != "" {
+		ready, err := strconv.ParseBool(v)
+		if err != nil {
+			return MemberQuery{}, fmt.Errorf("invalid ready value %q: %v", v, err)
+		}
+		q.Ready = &ready
+	}
+
+	for _, phase := range c.QueryArray("pod_phase") {
+		q.PodPhases = append(q.PodPhases, v1.PodPhase(phase))
+	}
+
+	if v := c.Query("pvc_bound"); v != "" {
+		bound, err := strconv.ParseBool(v)
+		if err != nil {
+			return MemberQuery{}, fmt.Errorf("invalid pvc_bound value %q: %v", v, err)
+		}
+		q.PVCBound = &bound
+	}
+
+	q.ImageID = c.Query("image_id")
+	q.VersionConstraint = c.Query("version")
+
+	return q, nil
+}